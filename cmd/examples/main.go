@@ -0,0 +1,123 @@
+// Command examples lists, runs and shows the "Go by Example" programs.
+//
+//	examples list [section]
+//	examples run <example> [arguments]
+//	examples show <example>
+//
+// An example is named by its directory, e.g. "range", its path, e.g.
+// "go-by-example-basics/range", or its title, e.g. "Multiple Return Values".
+// Examples are run with "go run", so the command must be used from within the module.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	examples "github.com/hieuvp/learning-golang"
+)
+
+func main() {
+	flag.Usage = func() {
+		out := flag.CommandLine.Output()
+		fmt.Fprintf(out, "usage: examples list [section]\n")
+		fmt.Fprintf(out, "       examples run <example> [arguments]\n")
+		fmt.Fprintf(out, "       examples show <example>\n")
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch cmd, args := args[0], args[1:]; {
+	case cmd == "list" && len(args) <= 1:
+		err = list(args)
+	case cmd == "run" && len(args) >= 1:
+		err = run(args[0], args[1:])
+	case cmd == "show" && len(args) == 1:
+		err = show(args[0])
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var exit *exec.ExitError
+	if errors.As(err, &exit) {
+		// "go run" already reported the failure
+		os.Exit(exit.ExitCode())
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func list(args []string) error {
+	sections, err := examples.Sections()
+	if err != nil {
+		return err
+	}
+
+	matched := false
+	for _, s := range sections {
+		if len(args) == 1 && args[0] != s.Dir && !strings.EqualFold(args[0], s.Title) {
+			continue
+		}
+		if matched {
+			fmt.Println()
+		}
+		matched = true
+
+		fmt.Printf("%s (%s)\n", s.Title, s.Dir)
+		width := 0
+		for _, e := range s.Examples {
+			width = max(width, len(e.Name))
+		}
+		for _, e := range s.Examples {
+			fmt.Printf("  %-*s  %s\n", width, e.Name, e.Title)
+		}
+	}
+
+	if !matched && len(args) == 1 {
+		return fmt.Errorf("examples: no section named %q", args[0])
+	}
+	return nil
+}
+
+func run(name string, args []string) error {
+	e, err := examples.Find(name)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command("go", append([]string{"run", e.Package()}, args...)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exit *exec.ExitError
+		if errors.As(err, &exit) {
+			return err
+		}
+		return fmt.Errorf("examples: %w", err)
+	}
+	return nil
+}
+
+func show(name string) error {
+	e, err := examples.Find(name)
+	if err != nil {
+		return err
+	}
+	src, err := e.Source()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("// %s\n\n%s", e.Dir(), src)
+	return nil
+}
@@ -0,0 +1,131 @@
+// Command extsort sorts text or CSV files that may be larger than the available memory.
+//
+//	extsort [flags] [file]
+//
+// The input is read from "file", or from standard input when it is omitted.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hieuvp/learning-golang/pkg/extsort"
+)
+
+func main() {
+	var (
+		memory    = flag.String("m", "64M", "memory budget for each sorted run, e.g. 512K, 64M or 2G")
+		fanIn     = flag.Int("fan-in", extsort.DefaultFanIn, "maximum number of runs merged at once")
+		tempDir   = flag.String("T", "", "directory for temporary files")
+		output    = flag.String("o", "", "write the result to this file instead of standard output")
+		csvMode   = flag.Bool("csv", false, "read and write CSV records instead of lines")
+		header    = flag.Bool("header", false, "keep the first record in place")
+		field     = flag.Int("k", 0, "1-based field used as the sort key, 0 for the whole record")
+		separator = flag.String("t", "", "field separator, white space (or \",\" for CSV) by default")
+		numeric   = flag.Bool("n", false, "compare keys as numbers")
+		reverse   = flag.Bool("r", false, "sort in descending order")
+		unique    = flag.Bool("u", false, "output only the first record of each distinct key")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: extsort [flags] [file]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	budget, err := parseSize(*memory)
+	if err != nil {
+		fatal(err)
+	}
+
+	var sep rune
+	if *separator != "" {
+		r, n := utf8.DecodeRuneInString(*separator)
+		if n != len(*separator) {
+			fatal(fmt.Errorf("separator must be a single character: %q", *separator))
+		}
+		sep = r
+	}
+
+	opts := extsort.Options{
+		MemoryBudget: budget,
+		FanIn:        *fanIn,
+		TempDir:      *tempDir,
+		CSV:          *csvMode,
+		Header:       *header,
+		Field:        *field,
+		Separator:    sep,
+		Numeric:      *numeric,
+		Reverse:      *reverse,
+		Unique:       *unique,
+	}
+
+	if err := run(flag.Arg(0), *output, opts); err != nil {
+		fatal(err)
+	}
+}
+
+func run(input, output string, opts extsort.Options) error {
+	// Sorting a file onto itself needs the result written aside first
+	if input != "" && output != "" {
+		return extsort.SortFile(input, output, opts)
+	}
+
+	var in io.Reader = os.Stdin
+	if input != "" {
+		f, err := os.Open(input)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	if output == "" {
+		return extsort.Sort(in, os.Stdout, opts)
+	}
+
+	out, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	if err := extsort.Sort(in, out, opts); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// parseSize parses a byte count with an optional K, M or G suffix
+func parseSize(s string) (int64, error) {
+	units := map[string]int64{"K": 1 << 10, "M": 1 << 20, "G": 1 << 30}
+
+	upper := strings.TrimSuffix(strings.ToUpper(s), "B")
+	mult := int64(1)
+	if len(upper) > 0 {
+		if m, ok := units[upper[len(upper)-1:]]; ok {
+			mult = m
+			upper = upper[:len(upper)-1]
+		}
+	}
+
+	n, err := strconv.ParseInt(upper, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid memory budget: %q", s)
+	}
+	return n * mult, nil
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "extsort: %v\n", err)
+	os.Exit(1)
+}
@@ -0,0 +1,108 @@
+// Command lint runs the analyzers of "go vet" and of this project over packages,
+// replacing the per-file "go vet" and "golint" of scripts/lint-go.sh which failed
+// on directories holding several main packages.
+//
+//	lint [-format text|json|sarif] [-vet=false] [-o file] [packages]
+//
+// Packages default to "./...". The command exits with status 1 when there are findings,
+// whatever the format, so it can be used in CI with the SARIF report uploaded.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/hieuvp/learning-golang/pkg/atomicfile"
+	"github.com/hieuvp/learning-golang/pkg/lint"
+)
+
+func main() {
+	var (
+		format = flag.String("format", "text", "output format: text, json or sarif")
+		vet    = flag.Bool("vet", true, `run the analyzers of "go vet" too`)
+		output = flag.String("o", "", "write the report to a file instead of the standard output")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: lint [-format text|json|sarif] [-vet=false] [-o file] [packages]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var write func(io.Writer, []lint.Finding, []*analysis.Analyzer) error
+	switch *format {
+	case "text":
+		write = func(w io.Writer, findings []lint.Finding, _ []*analysis.Analyzer) error {
+			return lint.WriteText(w, findings)
+		}
+	case "json":
+		write = func(w io.Writer, findings []lint.Finding, _ []*analysis.Analyzer) error {
+			return lint.WriteJSON(w, findings)
+		}
+	case "sarif":
+		write = lint.WriteSARIF
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	analyzers := lint.Project
+	if *vet {
+		analyzers = slices.Concat(lint.Vet, lint.Project)
+	}
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	cfg := &packages.Config{Mode: packages.LoadAllSyntax, Tests: true}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		fatal(err)
+	}
+
+	findings, err := lint.Run(analyzers, pkgs)
+	if err != nil {
+		fatal(err)
+	}
+	relative(findings)
+
+	if *output == "" {
+		err = write(os.Stdout, findings, analyzers)
+	} else {
+		err = atomicfile.WriteFile(*output, func(w io.Writer) error {
+			return write(w, findings, analyzers)
+		})
+	}
+	if err != nil {
+		fatal(err)
+	}
+	if len(findings) > 0 {
+		os.Exit(1)
+	}
+}
+
+// relative makes the files of the findings relative to the current directory,
+// which the SARIF report takes as the root of the sources
+func relative(findings []lint.Finding) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	for i, f := range findings {
+		if rel, err := filepath.Rel(wd, f.File); err == nil && filepath.IsLocal(rel) {
+			findings[i].File = rel
+		}
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "lint: %v\n", err)
+	os.Exit(1)
+}
@@ -0,0 +1,89 @@
+// Command mdcode keeps the code embedded in Markdown files in sync with the sources.
+//
+//	mdcode [-check] [file.md ...]
+//
+// It rewrites the content between markers such as
+//
+//	<!-- AUTO-GENERATED-CONTENT:START (CODE:src=range/main.go&lines=5-12) -->
+//	<!-- AUTO-GENERATED-CONTENT:END -->
+//
+// with the referenced source, see transform for the options. Without files,
+// every README.md of the module is processed. With -check, nothing is written
+// and the command fails when a file is not up to date.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/hieuvp/learning-golang/pkg/atomicfile"
+	"github.com/hieuvp/learning-golang/pkg/markdown"
+)
+
+func main() {
+	check := flag.Bool("check", false, "only report the files that are not up to date")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: mdcode [-check] [file.md ...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	files := flag.Args()
+	if len(files) == 0 {
+		var err error
+		if files, err = markdown.ModuleFiles(); err != nil {
+			fatal(err)
+		}
+	}
+
+	stale := 0
+	for _, file := range files {
+		changed, err := process(file, *check)
+		if err != nil {
+			fatal(err)
+		}
+		switch {
+		case changed && *check:
+			stale++
+			fmt.Printf("%s is not up to date\n", file)
+		case changed:
+			fmt.Printf("updated %s\n", file)
+		}
+	}
+	if stale > 0 {
+		fmt.Printf("run mdcode to update %d file(s)\n", stale)
+		os.Exit(1)
+	}
+}
+
+// process updates the markers of a file and reports whether its content changed
+func process(file string, check bool) (bool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+	updated, err := update(data, filepath.Dir(file))
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", file, err)
+	}
+	if bytes.Equal(data, updated) {
+		return false, nil
+	}
+	if check {
+		return true, nil
+	}
+
+	return true, atomicfile.WriteFile(file, func(w io.Writer) error {
+		_, err := w.Write(updated)
+		return err
+	})
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "mdcode: %v\n", err)
+	os.Exit(1)
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	startMarker = regexp.MustCompile(`^<!-- AUTO-GENERATED-CONTENT:START \((\w+)(?::(.*))?\) -->$`)
+	endMarker   = "<!-- AUTO-GENERATED-CONTENT:END -->"
+)
+
+// languages maps file extensions to the language of their code blocks
+var languages = map[string]string{
+	".go":   "go",
+	".sh":   "bash",
+	".json": "json",
+	".yaml": "yaml",
+	".yml":  "yaml",
+	".md":   "markdown",
+	".mod":  "go",
+	".tmpl": "go",
+}
+
+// runTimeout limits how long a program of an OUTPUT marker may run
+const runTimeout = 2 * time.Minute
+
+// update replaces the content of every known marker of a Markdown document.
+// Paths in markers are relative to "dir", the directory of the document
+func update(data []byte, dir string) ([]byte, error) {
+	lines := strings.SplitAfter(string(data), "\n")
+
+	var out strings.Builder
+	for i := 0; i < len(lines); i++ {
+		out.WriteString(lines[i])
+		m := startMarker.FindStringSubmatch(strings.TrimRight(lines[i], "\r\n"))
+		if m == nil {
+			continue
+		}
+
+		end := i + 1
+		for end < len(lines) && strings.TrimRight(lines[end], "\r\n") != endMarker {
+			end++
+		}
+		if end == len(lines) {
+			return nil, fmt.Errorf("%d: %s marker without %s", i+1, m[1], endMarker)
+		}
+
+		content, ok, err := transform(m[1], m[2], dir)
+		if err != nil {
+			return nil, fmt.Errorf("%d: %w", i+1, err)
+		}
+		if !ok {
+			// Leave the markers of other tools alone
+			continue
+		}
+		out.WriteString(content)
+		i = end - 1
+	}
+	return []byte(out.String()), nil
+}
+
+// transform generates the content of a marker, the same way as markdown-magic did:
+//
+//	CODE:src=path&lines=A-B&syntax=lang
+//		the source file, or its lines A to B (1-based, inclusive; "A-" up to the end),
+//		in a code block of the language of its extension unless "syntax" is given
+//	OUTPUT:src=dir
+//		the standard output of "go run ./dir", in a text code block
+//
+// It returns false for the transforms it does not know
+func transform(name, args string, dir string) (string, bool, error) {
+	opts, err := url.ParseQuery(args)
+	if err != nil {
+		return "", false, fmt.Errorf("%s: invalid options %q", name, args)
+	}
+	src := opts.Get("src")
+	if (name == "CODE" || name == "OUTPUT") && src == "" {
+		return "", false, fmt.Errorf("%s: missing src option", name)
+	}
+
+	switch name {
+	case "CODE":
+		code, err := readLines(filepath.Join(dir, src), opts.Get("lines"))
+		if err != nil {
+			return "", false, err
+		}
+		syntax := opts.Get("syntax")
+		if syntax == "" {
+			syntax = languages[filepath.Ext(src)]
+		}
+		header := "<!-- The below code snippet is automatically added from " + src + " -->"
+		return block(header, syntax, code), true, nil
+
+	case "OUTPUT":
+		output, err := run(dir, src)
+		if err != nil {
+			return "", false, err
+		}
+		header := "<!-- The below output is automatically added by running " + src + " -->"
+		return block(header, "text", output), true, nil
+	}
+	return "", false, nil
+}
+
+func block(header, syntax, body string) string {
+	return header + "\n\n```" + syntax + "\n" + strings.TrimRight(body, "\n") + "\n```\n\n"
+}
+
+// readLines returns the content of a file, or the lines of a range such as "5-12" or "5-"
+func readLines(path, spec string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if spec == "" {
+		return string(data), nil
+	}
+
+	lines := strings.SplitAfter(string(data), "\n")
+	if len(lines) > 1 && lines[len(lines)-1] == "" {
+		// A final new line ends the last line, it does not start another one
+		lines = lines[:len(lines)-1]
+	}
+	from, to, ok := strings.Cut(spec, "-")
+	first, err1 := strconv.Atoi(from)
+	last, err2 := len(lines), error(nil)
+	if to != "" {
+		last, err2 = strconv.Atoi(to)
+	}
+	if !ok || err1 != nil || err2 != nil || first < 1 || last < first || last > len(lines) {
+		return "", fmt.Errorf("invalid line range %q for %s, which has %d lines", spec, path, len(lines))
+	}
+	return strings.Join(lines[first-1:last], ""), nil
+}
+
+// run returns the standard output of the program in "src"
+func run(dir, src string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), runTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "go", "run", "./"+filepath.ToSlash(filepath.Clean(src)))
+	cmd.Dir = dir
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("running %s: %v\n%s", src, err, stderr.Bytes())
+	}
+	return stdout.String(), nil
+}
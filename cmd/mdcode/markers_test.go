@@ -0,0 +1,194 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const source = "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hello\")\n}\n"
+
+// writeFiles creates the files of a test module in a temporary directory and returns it
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func marker(args string) string {
+	return "<!-- AUTO-GENERATED-CONTENT:START (" + args + ") -->\n"
+}
+
+func TestUpdate(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"hello/main.go": source,
+		"run.sh":        "#!/bin/sh\necho hi",
+	})
+	const header = "<!-- The below code snippet is automatically added from "
+
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{
+			"whole file",
+			"# Hello\n" + marker("CODE:src=hello/main.go") + "stale\n" + endMarker + "\nafter\n",
+			"# Hello\n" + marker("CODE:src=hello/main.go") +
+				header + "hello/main.go -->\n\n```go\n" + source + "```\n\n" + endMarker + "\nafter\n",
+		},
+		{
+			"line range",
+			marker("CODE:src=hello/main.go&lines=5-7") + endMarker + "\n",
+			marker("CODE:src=hello/main.go&lines=5-7") +
+				header + "hello/main.go -->\n\n```go\nfunc main() {\n\tfmt.Println(\"hello\")\n}\n```\n\n" + endMarker + "\n",
+		},
+		{
+			"open range",
+			marker("CODE:src=hello/main.go&lines=7-") + endMarker + "\n",
+			marker("CODE:src=hello/main.go&lines=7-") +
+				header + "hello/main.go -->\n\n```go\n}\n```\n\n" + endMarker + "\n",
+		},
+		{
+			"syntax",
+			marker("CODE:src=hello/main.go&lines=1-1&syntax=text") + endMarker + "\n",
+			marker("CODE:src=hello/main.go&lines=1-1&syntax=text") +
+				header + "hello/main.go -->\n\n```text\npackage main\n```\n\n" + endMarker + "\n",
+		},
+		{
+			"language of the extension, no final new line",
+			marker("CODE:src=run.sh&lines=2-2") + endMarker + "\n",
+			marker("CODE:src=run.sh&lines=2-2") +
+				header + "run.sh -->\n\n```bash\necho hi\n```\n\n" + endMarker + "\n",
+		},
+		{
+			"other tools",
+			marker("TOC") + "kept\n" + endMarker + "\n",
+			marker("TOC") + "kept\n" + endMarker + "\n",
+		},
+		{
+			"up to date",
+			"no markers\r\n",
+			"no markers\r\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := update([]byte(tt.doc), dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("update =\n%s\nwant\n%s", got, tt.want)
+			}
+			again, err := update(got, dir)
+			if err != nil || string(again) != string(got) {
+				t.Errorf("a second update changed the document: %v\n%s", err, again)
+			}
+		})
+	}
+}
+
+func TestUpdateErrors(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"hello/main.go": source})
+
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{"missing end", "\n" + marker("CODE:src=hello/main.go") + "content\n", "2: CODE marker without " + endMarker},
+		{"missing src", marker("CODE:lines=1-2") + endMarker + "\n", "1: CODE: missing src option"},
+		{"invalid options", marker("CODE:src=%zz") + endMarker + "\n", `1: CODE: invalid options "src=%zz"`},
+		{"missing file", marker("CODE:src=nope.go") + endMarker + "\n", "nope.go"},
+		{"past the end", marker("CODE:src=hello/main.go&lines=7-8") + endMarker + "\n", `invalid line range "7-8"`},
+		{"past the end of an open range", marker("CODE:src=hello/main.go&lines=8-") + endMarker + "\n", `invalid line range "8-"`},
+		{"reversed", marker("CODE:src=hello/main.go&lines=3-2") + endMarker + "\n", `invalid line range "3-2"`},
+		{"zero", marker("CODE:src=hello/main.go&lines=0-2") + endMarker + "\n", `invalid line range "0-2"`},
+		{"no dash", marker("CODE:src=hello/main.go&lines=3") + endMarker + "\n", `invalid line range "3"`},
+		{"not a number", marker("CODE:src=hello/main.go&lines=a-b") + endMarker + "\n", `invalid line range "a-b"`},
+		{"output without src", marker("OUTPUT:") + endMarker + "\n", "1: OUTPUT: missing src option"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := update([]byte(tt.doc), dir)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("update = %q, %v, want an error containing %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadLinesCount(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"seven.go": source})
+	_, err := readLines(filepath.Join(dir, "seven.go"), "1-8")
+	if err == nil || !strings.Contains(err.Error(), "which has 7 lines") {
+		t.Errorf("readLines one line past the end: %v", err)
+	}
+}
+
+func TestUpdateOutput(t *testing.T) {
+	if testing.Short() {
+		t.Skip("building and running a program")
+	}
+	dir := writeFiles(t, map[string]string{
+		"go.mod":        "module example.com/hello\n\ngo 1.23\n",
+		"hello/main.go": source,
+		"fail/main.go":  "package main\n\nimport \"os\"\n\nfunc main() { os.Exit(3) }\n",
+	})
+
+	doc := marker("OUTPUT:src=hello") + endMarker + "\n"
+	want := marker("OUTPUT:src=hello") +
+		"<!-- The below output is automatically added by running hello -->\n\n```text\nhello\n```\n\n" + endMarker + "\n"
+	got, err := update([]byte(doc), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("update =\n%s\nwant\n%s", got, want)
+	}
+
+	_, err = update([]byte(marker("OUTPUT:src=fail")+endMarker+"\n"), dir)
+	if err == nil || !strings.Contains(err.Error(), "running fail") {
+		t.Errorf("update with a failing program: %v", err)
+	}
+}
+
+func TestProcess(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"hello/main.go": source,
+		"README.md":     marker("CODE:src=hello/main.go&lines=1-1") + endMarker + "\n",
+		"BROKEN.md":     marker("CODE:src=hello/main.go") + "\n",
+	})
+	readme := filepath.Join(dir, "README.md")
+
+	for _, check := range []bool{true, false, false} {
+		before, _ := os.ReadFile(readme)
+		changed, err := process(readme, check)
+		if err != nil {
+			t.Fatal(err)
+		}
+		after, _ := os.ReadFile(readme)
+		if wantChanged := !strings.Contains(string(before), "package main"); changed != wantChanged {
+			t.Errorf("process(check %t) reported changed %t, want %t", check, changed, wantChanged)
+		}
+		if check && string(after) != string(before) {
+			t.Error("process with check rewrote the file")
+		}
+	}
+
+	broken := filepath.Join(dir, "BROKEN.md")
+	if _, err := process(broken, false); err == nil || !strings.HasPrefix(err.Error(), broken+": 1: ") {
+		t.Errorf("process of a file with an error: %v, want it prefixed with %q", err, broken+": 1: ")
+	}
+}
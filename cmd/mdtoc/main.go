@@ -0,0 +1,84 @@
+// Command mdtoc writes the table of contents of Markdown files between the markers
+// of doctoc, with the anchors GitHub gives to headings.
+//
+//	mdtoc [-check] [-min level] [-max level] [file.md ...]
+//
+// Without files, every README.md of the module is processed. With -check,
+// nothing is written and the command fails when a table is not up to date.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hieuvp/learning-golang/pkg/atomicfile"
+	"github.com/hieuvp/learning-golang/pkg/markdown"
+)
+
+func main() {
+	var (
+		check = flag.Bool("check", false, "only report the files whose table of contents is not up to date")
+		opts  markdown.TOCOptions
+	)
+	flag.IntVar(&opts.MinLevel, "min", 2, "lowest heading level listed")
+	flag.IntVar(&opts.MaxLevel, "max", 6, "highest heading level listed")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: mdtoc [-check] [-min level] [-max level] [file.md ...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	files := flag.Args()
+	if len(files) == 0 {
+		var err error
+		if files, err = markdown.ModuleFiles(); err != nil {
+			fatal(err)
+		}
+	}
+
+	stale := 0
+	for _, file := range files {
+		changed, err := process(file, opts, *check)
+		if err != nil {
+			fatal(err)
+		}
+		switch {
+		case changed && *check:
+			stale++
+			fmt.Printf("%s: table of contents is not up to date\n", file)
+		case changed:
+			fmt.Printf("updated %s\n", file)
+		}
+	}
+	if stale > 0 {
+		fmt.Printf("run mdtoc to update %d file(s)\n", stale)
+		os.Exit(1)
+	}
+}
+
+func process(file string, opts markdown.TOCOptions, check bool) (bool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+	updated, err := markdown.UpdateTOC(data, opts)
+	if err != nil {
+		return false, fmt.Errorf("%s:%w", file, err)
+	}
+	if bytes.Equal(data, updated) || check {
+		return !bytes.Equal(data, updated), nil
+	}
+
+	return true, atomicfile.WriteFile(file, func(w io.Writer) error {
+		_, err := w.Write(updated)
+		return err
+	})
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "mdtoc: %v\n", err)
+	os.Exit(1)
+}
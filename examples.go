@@ -0,0 +1,178 @@
+// Package examples embeds the "Go by Example" programs of this repository,
+// each one a command in its own directory, e.g. "go-by-example-basics/range",
+// along with the README of every section, which gives their order and titles
+package examples
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ModulePath is the import path of the module that holds the examples
+const ModulePath = "github.com/hieuvp/learning-golang"
+
+//go:embed go-by-example-*/README.md go-by-example-*/*/*.go
+var sources embed.FS
+
+// Example is a runnable example program
+type Example struct {
+	Section string // directory of the section, e.g. "go-by-example-basics"
+	Name    string // directory of the example, e.g. "hello-world"
+	Title   string // heading of the example in the README, e.g. "Hello World"
+}
+
+// Dir returns the directory of the example, relative to the module root
+func (e Example) Dir() string {
+	return path.Join(e.Section, e.Name)
+}
+
+// Package returns the import path of the example, which "go run" accepts
+func (e Example) Package() string {
+	return ModulePath + "/" + e.Dir()
+}
+
+// Source returns the Go files of the example, concatenated in name order
+func (e Example) Source() (string, error) {
+	files, err := fs.Glob(sources, e.Dir()+"/*.go")
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		return "", fmt.Errorf("examples: no source for %s", e.Dir())
+	}
+
+	var b strings.Builder
+	for i, name := range files {
+		data, err := sources.ReadFile(name)
+		if err != nil {
+			return "", err
+		}
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.Write(data)
+	}
+	return b.String(), nil
+}
+
+// Section is a group of examples with its own README
+type Section struct {
+	Dir      string // e.g. "go-by-example-basics"
+	Title    string // e.g. "Go by Example - Basics"
+	Examples []Example
+}
+
+var (
+	headingPattern = regexp.MustCompile(`^(#{1,2}) +(.+?) *$`)
+	markerPattern  = regexp.MustCompile(`CODE:src=([^/)]+)/main\.go`)
+)
+
+// Sections returns the sections that have examples, in directory order.
+// Examples are in the order of the README, followed by any it does not mention
+func Sections() ([]Section, error) {
+	return sectionsOf(sources)
+}
+
+// sectionsOf reads the sections of the files of "fsys", laid out like the module
+func sectionsOf(fsys fs.FS) ([]Section, error) {
+	readmes, err := fs.Glob(fsys, "*/README.md")
+	if err != nil {
+		return nil, err
+	}
+
+	var sections []Section
+	for _, readme := range readmes {
+		s, err := parseSection(fsys, readme)
+		if err != nil {
+			return nil, err
+		}
+		if len(s.Examples) > 0 {
+			sections = append(sections, s)
+		}
+	}
+	return sections, nil
+}
+
+func parseSection(fsys fs.FS, readme string) (Section, error) {
+	data, err := fs.ReadFile(fsys, readme)
+	if err != nil {
+		return Section{}, err
+	}
+	s := Section{Dir: path.Dir(readme), Title: path.Dir(readme)}
+
+	// The code markers of the README come right after the heading of their example
+	seen := map[string]bool{}
+	heading, fenced := "", false
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "```") {
+			fenced = !fenced
+			continue
+		}
+		if m := headingPattern.FindStringSubmatch(line); m != nil && !fenced {
+			if m[1] == "#" {
+				s.Title = m[2]
+			} else {
+				heading = m[2]
+			}
+			continue
+		}
+		m := markerPattern.FindStringSubmatch(line)
+		if m == nil || seen[m[1]] {
+			continue
+		}
+		seen[m[1]] = true
+		s.Examples = append(s.Examples, Example{Section: s.Dir, Name: m[1], Title: heading})
+	}
+
+	dirs, err := fs.Glob(fsys, s.Dir+"/*/main.go")
+	if err != nil {
+		return Section{}, err
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		name := path.Base(path.Dir(dir))
+		if !seen[name] {
+			s.Examples = append(s.Examples, Example{Section: s.Dir, Name: name, Title: name})
+		}
+	}
+	return s, nil
+}
+
+// Find returns the example called "name", which is either the name of its directory,
+// e.g. "range", its path from the module root, e.g. "go-by-example-basics/range",
+// or its title in any case, e.g. "Multiple Return Values"
+func Find(name string) (Example, error) {
+	sections, err := Sections()
+	if err != nil {
+		return Example{}, err
+	}
+	return find(sections, name)
+}
+
+func find(sections []Section, name string) (Example, error) {
+	var found []Example
+	for _, s := range sections {
+		for _, e := range s.Examples {
+			if name == e.Name || name == e.Dir() || strings.EqualFold(name, e.Title) {
+				found = append(found, e)
+			}
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return Example{}, fmt.Errorf("examples: no example named %q", name)
+	case 1:
+		return found[0], nil
+	}
+	dirs := make([]string, len(found))
+	for i, e := range found {
+		dirs[i] = e.Dir()
+	}
+	return Example{}, fmt.Errorf("examples: %q is ambiguous, one of %s", name, strings.Join(dirs, ", "))
+}
@@ -0,0 +1,124 @@
+package examples
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func code(src string) string {
+	return "<!-- AUTO-GENERATED-CONTENT:START (CODE:src=" + src + ") -->\n<!-- AUTO-GENERATED-CONTENT:END -->\n"
+}
+
+var testFS = fstest.MapFS{
+	"alpha/README.md": {Data: []byte("# Alpha Section\n\n" +
+		"- [Second](#second)\n\n" +
+		"## Second\n\n" + code("second/main.go") +
+		"```go\n## Not a Heading\n```\n\n" +
+		"## First\n\n" + code("first/main.go&lines=3-") + code("first/main.go&lines=1-2") +
+		"## Again\n\n" + code("second/main.go"))},
+	"alpha/first/main.go":    {Data: []byte("package main\n")},
+	"alpha/second/main.go":   {Data: []byte("package main\n")},
+	"alpha/unlisted/main.go": {Data: []byte("package main\n")},
+	"beta/README.md":         {Data: []byte("# Beta\n\nNo examples yet\n")},
+	"gamma/README.md":        {Data: []byte("## First\n\n" + code("one/main.go"))},
+	"gamma/one/main.go":      {Data: []byte("package main\n")},
+	"gamma/second/main.go":   {Data: []byte("package main\n")},
+}
+
+func TestSectionsOf(t *testing.T) {
+	got, err := sectionsOf(testFS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Section{
+		{Dir: "alpha", Title: "Alpha Section", Examples: []Example{
+			{Section: "alpha", Name: "second", Title: "Second"},
+			{Section: "alpha", Name: "first", Title: "First"},
+			{Section: "alpha", Name: "unlisted", Title: "unlisted"},
+		}},
+		{Dir: "gamma", Title: "gamma", Examples: []Example{
+			{Section: "gamma", Name: "one", Title: "First"},
+			{Section: "gamma", Name: "second", Title: "second"},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sectionsOf =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestFind(t *testing.T) {
+	sections, err := sectionsOf(testFS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		want string // directory of the example, or the start of the error
+	}{
+		{"alpha/first", "alpha/first"},
+		{"one", "gamma/one"},
+		{"Alpha Section", `examples: no example named "Alpha Section"`},
+		{"gamma/second", "gamma/second"},
+		{"alpha/second", "alpha/second"},
+		{"unlisted", "alpha/unlisted"},
+		{"UNLISTED", "alpha/unlisted"},
+		{"second", `examples: "second" is ambiguous, one of alpha/second, gamma/second`},
+		{"first", `examples: "first" is ambiguous, one of alpha/first, gamma/one`},
+		{"FIRST", `examples: "FIRST" is ambiguous, one of alpha/first, gamma/one`},
+		{"alpha", `examples: no example named "alpha"`},
+		{"Not a Heading", `examples: no example named "Not a Heading"`},
+	}
+	for _, tt := range tests {
+		e, err := find(sections, tt.name)
+		got := e.Dir()
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tt.want {
+			t.Errorf("find(%q) = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSections(t *testing.T) {
+	sections, err := Sections()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dirs []string
+	for _, s := range sections {
+		dirs = append(dirs, s.Dir)
+	}
+	// The advanced section has no examples yet
+	if want := []string{"go-by-example-basics", "go-by-example-concurrency"}; !reflect.DeepEqual(dirs, want) {
+		t.Fatalf("sections %q, want %q", dirs, want)
+	}
+
+	basics := sections[0]
+	if basics.Title != "Go by Example - Basics" {
+		t.Errorf("title %q", basics.Title)
+	}
+	if first := basics.Examples[0]; first != (Example{"go-by-example-basics", "hello-world", "Hello World"}) {
+		t.Errorf("first example %+v", first)
+	}
+
+	for _, name := range []string{"range", "go-by-example-basics/range", "RANGE"} {
+		e, err := Find(name)
+		if err != nil || e.Dir() != "go-by-example-basics/range" {
+			t.Errorf("Find(%q) = %+v, %v", name, e, err)
+		}
+	}
+	e, err := Find("multiple return values")
+	if err != nil || e.Name != "multiple-return-values" {
+		t.Errorf("Find by title = %+v, %v", e, err)
+	}
+	if src, err := e.Source(); err != nil || !strings.HasPrefix(src, "package main") {
+		t.Errorf("Source of %s: %v", e.Dir(), err)
+	}
+	if _, err := Find("no such example"); err == nil {
+		t.Error("Find of an unknown example should fail")
+	}
+}
@@ -1,6 +1,10 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/hieuvp/learning-golang/pkg/ordered"
+)
 
 func main() {
 
@@ -20,13 +24,15 @@ func main() {
 	fmt.Println("Sum:", sum)
 
 	// range on maps iterates over key/value pairs
+	// The iteration order of a map is not specified and changes from run to run,
+	// "ordered.Sorted" visits the keys in ascending order instead
 	kvs := map[string]string{"a": "apple", "b": "banana"}
-	for key, value := range kvs {
+	for key, value := range ordered.Sorted(kvs) {
 		fmt.Printf("%s -> %s\n", key, value)
 	}
 
 	// range can also iterate over just the keys of a map
-	for key := range kvs {
+	for key := range ordered.Sorted(kvs) {
 		fmt.Println("Key:", key)
 	}
 
@@ -9,6 +9,9 @@ import (
 // 1. Create a file
 // 2. Write to it
 // 3. Then close when we are done
+//
+// The file is written in place and a failure ends the program, which keeps
+// the example about "defer"; "pkg/atomicfile" shows how real code writes a file safely
 func main() {
 
 	file := createFile("/tmp/defer.txt")
@@ -26,11 +26,22 @@ func main() {
 	var readOps uint64
 	var writeOps uint64
 
+	// Closing "done" tells every goroutine to return, "wg" waits until they all have
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
 	// Here we start "100" goroutines to execute repeated reads against the "state"
 	for r := 0; r < 100; r++ {
+		wg.Add(1)
 		go func() {
+			defer wg.Done()
 			total := 0
 			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
 
 				// For each read, we pick a random "key" to access
 				key := rand.Intn(5)
@@ -55,8 +66,15 @@ func main() {
 
 	// We will also start "10" goroutines to simulate writes
 	for w := 0; w < 10; w++ {
+		wg.Add(1)
 		go func() {
+			defer wg.Done()
 			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
 				key := rand.Intn(5)
 				value := rand.Intn(100)
 
@@ -72,6 +90,8 @@ func main() {
 
 	// Let our goroutines work on the "state" and "mutex" for "3s"
 	time.Sleep(3 * time.Second)
+	close(done)
+	wg.Wait()
 
 	// Take and report final operation counts
 	finalReadOps := atomic.LoadUint64(&readOps)
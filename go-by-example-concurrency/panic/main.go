@@ -10,7 +10,8 @@ func main() {
 	// to abort if a function returns an error value
 	// that we do not know how to handle or do not want to handle
 
-	// Panicking if we get an unexpected error when creating a new file
+	// Panicking if we get an unexpected error when creating a new file,
+	// "pkg/atomicfile" returns such errors to the caller instead
 	_, err := os.Create("/tmp/file")
 	if err != nil {
 		panic(err)
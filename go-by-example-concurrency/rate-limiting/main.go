@@ -46,16 +46,32 @@ func main() {
 
 	// Every "200ms" we will try to add a new value to "burstyLimiter",
 	// up to its limit of "3"
+	// Unlike "time.Tick", a "time.NewTicker" can be stopped,
+	// and closing "done" tells the goroutine to return once the requests are served
+	ticker := time.NewTicker(duration)
+	defer ticker.Stop()
+	done := make(chan struct{})
+	defer close(done)
+
 	go func() {
 		fmt.Printf("Initializing Ticker for every %s\n\n", duration)
 
-		for t := range time.Tick(duration) {
-			fmt.Println("Ticking                    : at", getMonotonicClock())
-			fmt.Println("Increasing Limiter length  :", len(burstyLimiter), "+ 1")
+		for {
+			select {
+			case <-done:
+				return
+			case t := <-ticker.C:
+				fmt.Println("Ticking                    : at", getMonotonicClock())
+				fmt.Println("Increasing Limiter length  :", len(burstyLimiter), "+ 1")
 
-			// Blocked when "burstyLimiter" reaches its limited buffered capacity
-			burstyLimiter <- t
-			fmt.Println("Increased Limiter length   : at", getMonotonicClock())
+				// Blocked when "burstyLimiter" reaches its limited buffered capacity
+				select {
+				case burstyLimiter <- t:
+					fmt.Println("Increased Limiter length   : at", getMonotonicClock())
+				case <-done:
+					return
+				}
+			}
 		}
 	}()
 
@@ -3,6 +3,9 @@ package main
 import (
 	"fmt"
 	"sort"
+	"strings"
+
+	"github.com/hieuvp/learning-golang/pkg/sortby"
 )
 
 // Sometimes, we will want to sort a collection by something other than its natural order
@@ -39,4 +42,12 @@ func main() {
 	sort.Sort(byLength(fruits))
 
 	fmt.Println(fruits)
+
+	// "pkg/sortby" builds the same ordering out of a key function, without a new type,
+	// and chains a second key to break the ties between strings of the same length
+	words := []string{"peach", "Fig", "banana", "kiwi", "fig", "apple"}
+	byLengthThenName := sortby.ThenByKey(sortby.By(func(s string) int { return len(s) }), strings.ToLower)
+	byLengthThenName.SortStable(words)
+
+	fmt.Println(words)
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"math/rand"
+	"sync"
 	"sync/atomic"
 	"time"
 )
@@ -37,9 +38,15 @@ func main() {
 	reads := make(chan readOperation)
 	writes := make(chan writeOperation)
 
+	// Closing "done" tells every goroutine to return, "wg" waits until they all have
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
 	// Here is the goroutine that owns the "state",
 	// which is a "map" as in the previous example but now private to this "stateful goroutine"
+	wg.Add(1)
 	go func() {
+		defer wg.Done()
 		var state = make(map[int]int)
 
 		// Repeatedly "select" on the "reads" and "writes" channels,
@@ -54,6 +61,8 @@ func main() {
 				state[write.key] = write.value
 				// Indicate success
 				write.response <- true
+			case <-done:
+				return
 			}
 		}
 	}()
@@ -61,7 +70,9 @@ func main() {
 	// This starts "100" goroutines to
 	// issue reads to the state-owning goroutine via the "reads" channel
 	for r := 0; r < 100; r++ {
+		wg.Add(1)
 		go func() {
+			defer wg.Done()
 			for {
 
 				// Each read requires constructing a "readOperation"
@@ -69,8 +80,12 @@ func main() {
 					key:      rand.Intn(5),
 					response: make(chan int)}
 
-				// Sending it over the "reads" channel
-				reads <- read
+				// Sending it over the "reads" channel, unless we are done
+				select {
+				case reads <- read:
+				case <-done:
+					return
+				}
 
 				// Receiving the result over the provided "read.response" channel
 				<-read.response
@@ -83,14 +98,20 @@ func main() {
 
 	// We start "10" writes as well, using a similar approach
 	for w := 0; w < 10; w++ {
+		wg.Add(1)
 		go func() {
+			defer wg.Done()
 			for {
 				write := writeOperation{
 					key:      rand.Intn(5),
 					value:    rand.Intn(100),
 					response: make(chan bool)}
 
-				writes <- write
+				select {
+				case writes <- write:
+				case <-done:
+					return
+				}
 				<-write.response
 
 				atomic.AddUint64(&writeOps, 1)
@@ -101,6 +122,8 @@ func main() {
 
 	// Let the goroutines work for "3s"
 	time.Sleep(3 * time.Second)
+	close(done)
+	wg.Wait()
 
 	// Finally, capture and report the operation counts
 	finalReadOps := atomic.LoadUint64(&readOps)
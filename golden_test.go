@@ -0,0 +1,156 @@
+package examples_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	examples "github.com/hieuvp/learning-golang"
+	"github.com/hieuvp/learning-golang/pkg/pretty"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// exampleTimeout is the time limit for each example
+const exampleTimeout = time.Minute
+
+// TestGolden checks that every example program still prints what it used to.
+//
+// Each example is built and run, and its standard output, standard error and exit code
+// are compared with its golden file in testdata/golden, once the parts that change
+// from one run to the next are normalized, see normalizer. With -update, the golden
+// files are rewritten from the current output instead. A single example is checked with
+//
+//	go test -run TestGolden/go-by-example-basics/range .
+func TestGolden(t *testing.T) {
+	if testing.Short() {
+		t.Skip("building and running every example")
+	}
+
+	root, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sections, err := examples.Sections()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bin := t.TempDir()
+	if err := build(root, bin, sections); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range sections {
+		for _, e := range s.Examples {
+			t.Run(e.Dir(), func(t *testing.T) {
+				t.Parallel()
+				if err := check(root, bin, e, *update); err != nil {
+					t.Error(err)
+				}
+			})
+		}
+	}
+}
+
+// binary returns where the executable of an example is built
+func binary(bin string, e examples.Example) string {
+	return filepath.Join(bin, e.Section, e.Name)
+}
+
+// build compiles the examples, one directory per section so that names cannot collide
+func build(root, bin string, sections []examples.Section) error {
+	for _, s := range sections {
+		pkgs := make([]string, len(s.Examples))
+		for i, e := range s.Examples {
+			pkgs[i] = "./" + e.Dir()
+		}
+
+		out := filepath.Join(bin, s.Dir) + string(filepath.Separator)
+		cmd := exec.Command("go", append([]string{"build", "-o", out}, pkgs...)...)
+		cmd.Dir = root
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("building %s: %v\n%s", s.Dir, err, output)
+		}
+	}
+	return nil
+}
+
+// check runs an example and compares its output with its golden file, or rewrites the file
+func check(root, bin string, e examples.Example, update bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), exampleTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, binary(bin, e))
+	cmd.Dir = root
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+
+	var exit *exec.ExitError
+	code := 0
+	switch {
+	case ctx.Err() != nil:
+		return fmt.Errorf("timed out after %v", exampleTimeout)
+	case errors.As(err, &exit):
+		code = exit.ExitCode()
+	case err != nil:
+		return err
+	}
+
+	path := goldenPath(root, e)
+	want, err := readGolden(path)
+	if err != nil && !(update && errors.Is(err, os.ErrNotExist)) {
+		if errors.Is(err, os.ErrNotExist) {
+			err = fmt.Errorf("no golden file %s, run with -update to create it", path)
+		}
+		return err
+	}
+
+	n := normalizer{root: root, unordered: want.unordered, unorderedLines: want.unorderedLines, replace: want.replace}
+	got := want
+	got.exitCode = code
+	got.stdout = n.normalize(stdout.String())
+	got.stderr = n.normalize(stderr.String())
+
+	if update {
+		return writeGolden(path, got)
+	}
+	if diffs := compare(want, got); diffs != nil {
+		return fmt.Errorf("output differs from %s:\n%s", path, diffs)
+	}
+	return nil
+}
+
+func goldenPath(root string, e examples.Example) string {
+	return filepath.Join(root, "testdata", "golden", e.Section, e.Name+".golden")
+}
+
+// compare reports the differences between the expected and the actual results,
+// line by line so that the paths of the differences are line numbers
+func compare(want, got golden) pretty.Diffs {
+	type output struct {
+		ExitCode int
+		Stdout   []string
+		Stderr   []string
+	}
+	return pretty.Diff(
+		output{want.exitCode, lines(want.stdout), lines(want.stderr)},
+		output{got.exitCode, lines(got.stdout), lines(got.stderr)},
+	)
+}
+
+func lines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,138 @@
+package examples_test
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// golden is the recorded result of an example. The file looks like:
+//
+//	# unordered
+//	exit 0
+//	-- stdout --
+//	...
+//	-- stderr --
+//	...
+//
+// Comment lines at the top are kept by -update, some of them normalize the output further:
+//
+//	# unordered
+//		compares the lines sorted, for examples whose goroutines print in no particular order
+//	# unordered: REGEXP
+//		compares sorted only the runs of consecutive lines that match, e.g. the lines
+//		printed by goroutines between two lines of the main goroutine
+//	# replace: REGEXP => TEXT
+//		replaces every match, e.g. "# replace: It's (before|after) noon => It's <time of day>"
+type golden struct {
+	comments       []string
+	unordered      bool
+	unorderedLines *regexp.Regexp
+	replace        []replacement
+	exitCode       int
+	stdout         string
+	stderr         string
+}
+
+const (
+	stdoutHeader = "-- stdout --"
+	stderrHeader = "-- stderr --"
+)
+
+func readGolden(path string) (golden, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return golden{}, err
+	}
+
+	var (
+		g       golden
+		section *strings.Builder
+		stdout  strings.Builder
+		stderr  strings.Builder
+	)
+	sc := bufio.NewScanner(strings.NewReader(string(data)))
+	sc.Buffer(nil, len(data)+1)
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		switch {
+		case section == nil && strings.HasPrefix(line, "#"):
+			g.comments = append(g.comments, line)
+			if err := g.directive(strings.TrimSpace(strings.TrimPrefix(line, "#"))); err != nil {
+				return golden{}, fmt.Errorf("%s:%d: %v", path, n, err)
+			}
+		case section == nil && strings.HasPrefix(line, "exit "):
+			code, err := strconv.Atoi(strings.TrimPrefix(line, "exit "))
+			if err != nil {
+				return golden{}, fmt.Errorf("%s:%d: invalid exit code", path, n)
+			}
+			g.exitCode = code
+		case line == stdoutHeader:
+			section = &stdout
+		case line == stderrHeader:
+			section = &stderr
+		case section != nil:
+			section.WriteString(line + "\n")
+		default:
+			return golden{}, fmt.Errorf("%s:%d: unexpected line before %q", path, n, stdoutHeader)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return golden{}, err
+	}
+
+	g.stdout, g.stderr = stdout.String(), stderr.String()
+	return g, nil
+}
+
+// directive applies a comment that changes how the output is normalized
+func (g *golden) directive(comment string) error {
+	if comment == "unordered" {
+		g.unordered = true
+		return nil
+	}
+	if expr, ok := strings.CutPrefix(comment, "unordered:"); ok {
+		pattern, err := regexp.Compile(strings.TrimSpace(expr))
+		if err != nil {
+			return err
+		}
+		g.unorderedLines = pattern
+		return nil
+	}
+
+	spec, ok := strings.CutPrefix(comment, "replace:")
+	if !ok {
+		return nil
+	}
+	expr, text, ok := strings.Cut(strings.TrimSpace(spec), " => ")
+	if !ok {
+		return fmt.Errorf("replace directive without \" => \"")
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return err
+	}
+	g.replace = append(g.replace, replacement{pattern, text})
+	return nil
+}
+
+func writeGolden(path string, g golden) error {
+	var b strings.Builder
+	for _, c := range g.comments {
+		b.WriteString(c + "\n")
+	}
+	fmt.Fprintf(&b, "exit %d\n", g.exitCode)
+	b.WriteString(stdoutHeader + "\n" + g.stdout)
+	if g.stderr != "" {
+		b.WriteString(stderrHeader + "\n" + g.stderr)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
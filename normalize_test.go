@@ -0,0 +1,110 @@
+package examples_test
+
+import (
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// normalizer replaces the parts of an output that change from one run to the next
+// with placeholders, e.g. "2026-10-19 10:00:00.123 +0000 UTC m=+0.001" becomes "<time>"
+type normalizer struct {
+	root           string
+	unordered      bool
+	unorderedLines *regexp.Regexp
+	replace        []replacement
+}
+
+type replacement struct {
+	pattern *regexp.Regexp
+	with    string
+}
+
+var replacements = []replacement{
+	// time.Time values, as printed by String or formatted as RFC 3339, with a monotonic clock reading
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2}| [+-]\d{4}( [A-Z][A-Za-z]*)?)?( m=[+-]\d+\.\d+)?`), "<time>"},
+
+	// Measured durations, which have a fraction or a unit below the second, e.g. "2.0012s" or "350µs"
+	{regexp.MustCompile(`\b(\d+h)?(\d+m)?(\d+\.\d+(s|ms|µs|ns)|\d+(ms|µs|ns))\b`), "<duration>"},
+
+	// Pointers and other addresses
+	{regexp.MustCompile(`0x[0-9a-f]{6,}`), "0x<address>"},
+
+	// Goroutine numbers and states of panics, their stack traces follow
+	{regexp.MustCompile(`(?m)^goroutine \d+ \[[^\]]+\]:$`), "goroutine <n> [<state>]:"},
+}
+
+// stackFrame matches the lines of a stack trace: functions with their arguments, and file positions
+var stackFrame = regexp.MustCompile(`^(\S.*\(.*\)|\t.*:\d+( \+0x[0-9a-f]+)?)$`)
+
+func (n normalizer) normalize(s string) string {
+	s = strings.ReplaceAll(s, n.root, "$ROOT")
+	s = strings.ReplaceAll(s, runtime.GOROOT(), "$GOROOT")
+	for _, r := range append(replacements, n.replace...) {
+		s = r.pattern.ReplaceAllString(s, r.with)
+	}
+
+	// Stack traces depend on the compiler and the runtime, only their first line is kept
+	lines := strings.SplitAfter(s, "\n")
+	out := make([]string, 0, len(lines))
+	inTrace := false
+	for _, line := range lines {
+		trimmed := strings.TrimSuffix(line, "\n")
+		switch {
+		case trimmed == "goroutine <n> [<state>]:":
+			inTrace = true
+			out = append(out, line, "<stack trace>\n")
+		case inTrace && stackFrame.MatchString(trimmed):
+		default:
+			inTrace = false
+			out = append(out, line)
+		}
+	}
+
+	if n.unordered {
+		sort.Strings(out)
+	}
+	if n.unorderedLines != nil {
+		sortRuns(out, n.unorderedLines)
+	}
+	return strings.Join(out, "")
+}
+
+// sortRuns sorts in place every run of consecutive lines matching "pattern"
+func sortRuns(lines []string, pattern *regexp.Regexp) {
+	for start := 0; start < len(lines); {
+		end := start
+		for end < len(lines) && pattern.MatchString(strings.TrimSuffix(lines[end], "\n")) {
+			end++
+		}
+		sort.Strings(lines[start:end])
+		start = max(end, start+1)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	g := golden{}
+	for _, d := range []string{`unordered: ^worker \d$`, `replace: id=\w+ => id=<id>`} {
+		if err := g.directive(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.directive("replace: no arrow"); err == nil {
+		t.Error("a replace directive without \" => \" should fail")
+	}
+
+	n := normalizer{root: "/src/module", unorderedLines: g.unorderedLines, replace: g.replace}
+	in := "start at 2026-10-19 10:00:00.123 +0000 UTC m=+0.001\n" +
+		"worker 2\nworker 1\n" +
+		"took 1.5ms in /src/module/main.go id=f00\n" +
+		"worker 3\nworker 0\n"
+	want := "start at <time>\n" +
+		"worker 1\nworker 2\n" +
+		"took <duration> in $ROOT/main.go id=<id>\n" +
+		"worker 0\nworker 3\n"
+	if got := n.normalize(in); got != want {
+		t.Errorf("normalize:\n%s\nwant:\n%s", got, want)
+	}
+}
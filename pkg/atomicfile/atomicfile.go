@@ -0,0 +1,205 @@
+// Package atomicfile writes files the safe way the defer example only hints at:
+// the content goes to a temporary file next to the target, which is synced and renamed
+// over the target on Commit, so readers see either the old file or the complete new one,
+// never a partial write. Errors, including the ones of Close, are returned instead of
+// ending the program, and an advisory lock keeps concurrent writers apart
+package atomicfile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Writer writes the new content of a file, which replaces the file on Commit.
+// Call Abort in a defer right after Create: it removes the temporary file
+// on errors and panics, and does nothing once the writer is committed
+type Writer struct {
+	path string
+	file *os.File
+	lock *Lock
+	err  error // first write error, which prevents the commit
+	done bool
+}
+
+type options struct {
+	perm    fs.FileMode
+	hasPerm bool
+	lock    bool
+}
+
+// Option configures a Writer built by Create
+type Option func(*options)
+
+// WithPerm sets the permissions of the file. By default an existing file keeps its own,
+// and a new one is created with 0644
+func WithPerm(perm fs.FileMode) Option {
+	return func(o *options) {
+		o.perm, o.hasPerm = perm, true
+	}
+}
+
+// WithLock holds the lock of the file, see LockFile, from Create until Commit or Abort,
+// waiting for any other writer that holds it
+func WithLock() Option {
+	return func(o *options) {
+		o.lock = true
+	}
+}
+
+// Create starts writing a new version of the file at "path".
+// Nothing is visible at "path" until Commit
+func Create(path string, opts ...Option) (*Writer, error) {
+	o := options{perm: 0o644}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if !o.hasPerm {
+		if info, err := os.Stat(path); err == nil {
+			o.perm = info.Mode().Perm()
+		}
+	}
+
+	w := &Writer{path: path}
+	if o.lock {
+		lock, err := LockFile(path)
+		if err != nil {
+			return nil, err
+		}
+		w.lock = lock
+	}
+
+	// The temporary file must be in the same directory, a rename across file systems is a copy.
+	// Dir gives "." for a bare file name, where Split would give "" and so os.TempDir
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		w.unlock()
+		return nil, err
+	}
+	w.file = f
+
+	if err := f.Chmod(o.perm); err != nil && !errors.Is(err, errors.ErrUnsupported) {
+		w.Abort()
+		return nil, err
+	}
+	return w, nil
+}
+
+// Name returns the path of the file being written
+func (w *Writer) Name() string {
+	return w.path
+}
+
+// Write writes to the temporary file. After a failed write, Commit fails too
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.done {
+		return 0, os.ErrClosed
+	}
+	n, err := w.file.Write(p)
+	if err != nil && w.err == nil {
+		w.err = err
+	}
+	return n, err
+}
+
+// WriteString is like Write but writes the contents of "s"
+func (w *Writer) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Commit flushes the content to the disk and replaces the file with it.
+// When the content cannot be written or renamed, the file is left untouched
+// and the temporary file is removed. The directory is synced last: if that fails
+// the file is already replaced, and the error only says the rename may not survive a crash
+func (w *Writer) Commit() error {
+	if w.done {
+		return os.ErrClosed
+	}
+	if w.err != nil {
+		w.Abort()
+		return fmt.Errorf("atomicfile: %s not written: %w", w.path, w.err)
+	}
+
+	tmp := w.file.Name()
+	err := w.file.Sync()
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, w.path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		w.finish()
+		return err
+	}
+
+	// Sync the directory too, or the rename itself may not survive a crash
+	err = syncDir(filepath.Dir(w.path))
+	w.finish()
+	if err != nil {
+		return fmt.Errorf("atomicfile: %s replaced but its directory not synced: %w", w.path, err)
+	}
+	return nil
+}
+
+// Abort discards what was written and removes the temporary file.
+// It does nothing after Commit or a previous Abort, so it is safe to defer
+func (w *Writer) Abort() error {
+	if w.done {
+		return nil
+	}
+	err := w.file.Close()
+	if removeErr := os.Remove(w.file.Name()); err == nil {
+		err = removeErr
+	}
+	w.finish()
+	return err
+}
+
+func (w *Writer) finish() {
+	w.done = true
+	w.unlock()
+}
+
+func (w *Writer) unlock() {
+	if w.lock != nil {
+		w.lock.Unlock()
+		w.lock = nil
+	}
+}
+
+// WriteFile atomically replaces the file at "path" with what "write" writes.
+// The file is left untouched when "write" returns an error or panics
+func WriteFile(path string, write func(w io.Writer) error, opts ...Option) error {
+	w, err := Create(path, opts...)
+	if err != nil {
+		return err
+	}
+	defer w.Abort()
+
+	if err := write(w); err != nil {
+		return err
+	}
+	return w.Commit()
+}
+
+// Close closes "c" and stores its error in "*errp" unless it already holds one,
+// so that deferred closes do not lose errors:
+//
+//	func save(path string) (err error) {
+//		f, err := os.Create(path)
+//		if err != nil {
+//			return err
+//		}
+//		defer atomicfile.Close(f, &err)
+//		...
+//	}
+func Close(c io.Closer, errp *error) {
+	if err := c.Close(); err != nil && *errp == nil {
+		*errp = err
+	}
+}
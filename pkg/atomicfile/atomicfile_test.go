@@ -0,0 +1,226 @@
+package atomicfile
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// entries returns the names in "dir", to check that no temporary file is left behind
+func entries(t *testing.T, dir string) []string {
+	t.Helper()
+	list, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range list {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestReplace(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.txt")
+	if err := os.WriteFile(path, []byte("old"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Abort()
+	if _, err := w.WriteString("new content"); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, path); got != "old" {
+		t.Errorf("before Commit the file holds %q", got)
+	}
+	if len(entries(t, dir)) != 2 {
+		t.Errorf("the temporary file is not next to the target: %v", entries(t, dir))
+	}
+
+	if err := w.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, path); got != "new content" {
+		t.Errorf("after Commit the file holds %q", got)
+	}
+	if names := entries(t, dir); len(names) != 1 {
+		t.Errorf("files left behind: %v", names)
+	}
+	if info, _ := os.Stat(path); runtime.GOOS != "windows" && info.Mode().Perm() != 0o600 {
+		t.Errorf("permissions %v, want the 0600 of the replaced file", info.Mode().Perm())
+	}
+
+	if err := w.Commit(); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("second Commit: %v", err)
+	}
+	if _, err := w.Write([]byte("x")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Write after Commit: %v", err)
+	}
+}
+
+func TestAbort(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data")
+	if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.WriteString("half written")
+	if err := w.Abort(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Abort(); err != nil {
+		t.Errorf("second Abort: %v", err)
+	}
+
+	if got := readFile(t, path); got != "old" {
+		t.Errorf("after Abort the file holds %q", got)
+	}
+	if names := entries(t, dir); len(names) != 1 {
+		t.Errorf("files left behind: %v", names)
+	}
+}
+
+// TestBareName writes a path without a directory, whose temporary file
+// belongs in the working directory and not in os.TempDir
+func TestBareName(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	w, err := Create("bare.txt", WithPerm(0o640))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Abort()
+	if filepath.Dir(w.file.Name()) != "." {
+		t.Errorf("temporary file %s is not in the working directory", w.file.Name())
+	}
+	w.WriteString("bare")
+	if err := w.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readFile(t, filepath.Join(dir, "bare.txt")); got != "bare" {
+		t.Errorf("the file holds %q", got)
+	}
+	if info, _ := os.Stat("bare.txt"); runtime.GOOS != "windows" && info.Mode().Perm() != 0o640 {
+		t.Errorf("permissions %v, want 0640", info.Mode().Perm())
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out")
+
+	if err := WriteFile(path, func(w io.Writer) error {
+		_, err := io.WriteString(w, "first")
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	failure := errors.New("failure")
+	if err := WriteFile(path, func(w io.Writer) error {
+		io.WriteString(w, "second")
+		return failure
+	}); !errors.Is(err, failure) {
+		t.Errorf("WriteFile returned %v", err)
+	}
+
+	func() {
+		defer func() { recover() }()
+		WriteFile(path, func(w io.Writer) error {
+			io.WriteString(w, "third")
+			panic("boom")
+		})
+	}()
+
+	if got := readFile(t, path); got != "first" {
+		t.Errorf("the file holds %q", got)
+	}
+	if names := entries(t, dir); len(names) != 1 {
+		t.Errorf("files left behind: %v", names)
+	}
+}
+
+func TestCreateMissingDir(t *testing.T) {
+	if _, err := Create(filepath.Join(t.TempDir(), "missing", "file")); err == nil {
+		t.Error("Create in a missing directory should fail")
+	}
+}
+
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "locked")
+	if lock, err := TryLockFile(path); errors.Is(err, errors.ErrUnsupported) {
+		t.Skip("no file locks on", runtime.GOOS)
+	} else if err == nil {
+		lock.Unlock()
+	}
+
+	w, err := Create(path, WithLock())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := TryLockFile(path); !errors.Is(err, ErrLocked) {
+		t.Errorf("TryLockFile while a writer holds the lock: %v", err)
+	}
+	if err := w.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	lock, err := TryLockFile(path)
+	if err != nil {
+		t.Fatalf("TryLockFile after Commit: %v", err)
+	}
+	if err := lock.Unlock(); err != nil {
+		t.Error(err)
+	}
+}
+
+type closer struct{ err error }
+
+func (c closer) Close() error { return c.err }
+
+func TestClose(t *testing.T) {
+	first, second := errors.New("first"), errors.New("second")
+
+	var err error
+	Close(closer{second}, &err)
+	if err != second {
+		t.Errorf("Close kept %v, want the close error", err)
+	}
+
+	err = first
+	Close(closer{second}, &err)
+	if err != first {
+		t.Errorf("Close replaced %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package atomicfile
+
+import (
+	"errors"
+	"os"
+)
+
+// ErrLocked is returned by TryLockFile when another writer holds the lock
+var ErrLocked = errors.New("atomicfile: file is locked")
+
+// Lock is an exclusive advisory lock on a file, held by a single process at a time.
+// It only keeps out the writers that take the lock too.
+//
+// The lock is taken on a companion file with a ".lock" suffix rather than on the file
+// itself, which Commit replaces. The companion file is left in place: removing it would
+// let two writers lock two different files
+type Lock struct {
+	file *os.File
+}
+
+// LockFile takes the lock of the file at "path", waiting for it to be released if needed
+func LockFile(path string) (*Lock, error) {
+	return lockPath(path, true)
+}
+
+// TryLockFile takes the lock of the file at "path", or returns ErrLocked if it is held
+func TryLockFile(path string) (*Lock, error) {
+	return lockPath(path, false)
+}
+
+func lockPath(path string, wait bool) (*Lock, error) {
+	f, err := os.OpenFile(path+".lock", os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(f, wait); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &Lock{f}, nil
+}
+
+// Unlock releases the lock
+func (l *Lock) Unlock() error {
+	err := unlockFile(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
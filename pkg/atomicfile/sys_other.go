@@ -0,0 +1,20 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package atomicfile
+
+import (
+	"errors"
+	"os"
+)
+
+func lockFile(*os.File, bool) error {
+	return errors.ErrUnsupported
+}
+
+func unlockFile(*os.File) error {
+	return errors.ErrUnsupported
+}
+
+func syncDir(string) error {
+	return nil
+}
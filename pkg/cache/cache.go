@@ -0,0 +1,51 @@
+// Package cache provides bounded in-memory caches with different eviction policies:
+// least recently used (LRU), least frequently used (LFU) and time to live (TTL).
+//
+// The caches themselves are plain maps with bookkeeping and are not safe for concurrent use,
+// wrap them with NewSync to share one between goroutines and to load missing values
+// only once however many goroutines ask for them at the same time.
+package cache
+
+// Cache is implemented by every eviction policy of this package
+type Cache[K comparable, V any] interface {
+	// Get returns the value stored for "key" and whether it was found
+	Get(key K) (V, bool)
+
+	// Set stores "value" for "key", evicting another entry when the cache is full
+	Set(key K, value V)
+
+	// Delete removes "key", reporting whether it was present
+	Delete(key K) bool
+
+	// Len returns the number of entries currently stored
+	Len() int
+
+	// Stats returns the counters collected since the cache was created
+	Stats() Stats
+}
+
+// Stats counts what happened to a cache
+type Stats struct {
+	Hits        uint64
+	Misses      uint64
+	Evictions   uint64
+	Expirations uint64
+}
+
+// HitRate returns the fraction of lookups that found a value, 0 when there were none
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// record counts a lookup as a hit or a miss
+func (s *Stats) record(hit bool) {
+	if hit {
+		s.Hits++
+	} else {
+		s.Misses++
+	}
+}
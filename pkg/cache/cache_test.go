@@ -0,0 +1,282 @@
+package cache
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLRU(t *testing.T) {
+	c := NewLRU[string, int](2)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a")    // "b" is now the least recently used
+	c.Set("c", 3) // evicts "b"
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("b should have been evicted")
+	}
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %d, %v", v, ok)
+	}
+	if keys := c.Keys(); !slices.Equal(keys, []string{"a", "c"}) {
+		t.Errorf("Keys = %v", keys)
+	}
+
+	c.Set("c", 30) // an update is a use, "a" goes next
+	c.Set("d", 4)
+	if keys := c.Keys(); !slices.Equal(keys, []string{"d", "c"}) {
+		t.Errorf("Keys = %v", keys)
+	}
+
+	if !c.Delete("d") || c.Delete("d") || c.Len() != 1 {
+		t.Error("Delete")
+	}
+	want := Stats{Hits: 2, Misses: 1, Evictions: 2}
+	if got := c.Stats(); got != want {
+		t.Errorf("Stats = %+v, want %+v", got, want)
+	}
+	if rate := c.Stats().HitRate(); rate != 2.0/3 {
+		t.Errorf("HitRate = %v", rate)
+	}
+	if (Stats{}).HitRate() != 0 {
+		t.Error("HitRate without lookups")
+	}
+}
+
+func TestLFU(t *testing.T) {
+	c := NewLFU[string, int](3)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+
+	// "c" was used once
+	c.Set("d", 4)
+	if _, ok := c.Get("c"); ok {
+		t.Error("c should have been evicted")
+	}
+
+	// "d" was used once, and is the only one
+	c.Set("e", 5)
+	if _, ok := c.Get("d"); ok {
+		t.Error("d should have been evicted")
+	}
+
+	// Deleting the only entry used once leaves a stale lowest count behind
+	c.Delete("e")
+	c.Set("f", 6)
+	c.Get("f")
+	c.Get("f")
+	c.Get("f")
+	c.Set("g", 7) // the cache is full: "b" has the lowest count
+	if _, ok := c.Get("b"); ok {
+		t.Error("b should have been evicted")
+	}
+	for _, k := range []string{"a", "f", "g"} {
+		if _, ok := c.Get(k); !ok {
+			t.Errorf("%s should be cached", k)
+		}
+	}
+}
+
+// model is a cache written the obvious slow way, for the random operations to be checked against
+type model struct {
+	keys  []int // least recently used first
+	uses  map[int]int
+	cap   int
+	byUse bool // LFU rather than LRU
+}
+
+func (m *model) use(k int) {
+	m.keys = slices.DeleteFunc(m.keys, func(x int) bool { return x == k })
+	m.keys = append(m.keys, k)
+	m.uses[k]++
+}
+
+func (m *model) get(k int) bool {
+	if !slices.Contains(m.keys, k) {
+		return false
+	}
+	m.use(k)
+	return true
+}
+
+func (m *model) set(k int) {
+	if !slices.Contains(m.keys, k) && len(m.keys) == m.cap {
+		victim := 0
+		for i, x := range m.keys {
+			if m.byUse && m.uses[x] < m.uses[m.keys[victim]] {
+				victim = i
+			}
+		}
+		delete(m.uses, m.keys[victim])
+		m.keys = slices.Delete(m.keys, victim, victim+1)
+	}
+	m.use(k)
+}
+
+func (m *model) remove(k int) bool {
+	if !slices.Contains(m.keys, k) {
+		return false
+	}
+	m.keys = slices.DeleteFunc(m.keys, func(x int) bool { return x == k })
+	delete(m.uses, k)
+	return true
+}
+
+func TestAgainstModel(t *testing.T) {
+	for _, byUse := range []bool{false, true} {
+		var c Cache[int, int]
+		if byUse {
+			c = NewLFU[int, int](8)
+		} else {
+			c = NewLRU[int, int](8)
+		}
+		m := &model{uses: map[int]int{}, cap: 8, byUse: byUse}
+		rng := rand.New(rand.NewPCG(1, 2))
+
+		for i := range 20_000 {
+			k := rng.IntN(20)
+			switch rng.IntN(5) {
+			case 0, 1:
+				v, ok := c.Get(k)
+				if want := m.get(k); ok != want || (ok && v != k) {
+					t.Fatalf("LFU %v, operation %d: Get(%d) = %d, %v, want %v", byUse, i, k, v, ok, want)
+				}
+			case 2, 3:
+				c.Set(k, k)
+				m.set(k)
+			case 4:
+				if got, want := c.Delete(k), m.remove(k); got != want {
+					t.Fatalf("LFU %v, operation %d: Delete(%d) = %v", byUse, i, k, got)
+				}
+			}
+			if c.Len() != len(m.keys) {
+				t.Fatalf("LFU %v, operation %d: Len = %d, want %d", byUse, i, c.Len(), len(m.keys))
+			}
+		}
+	}
+}
+
+func TestTTL(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	c := NewTTL[string, int](time.Minute, 2)
+	c.Now = func() time.Time { return now }
+
+	c.Set("a", 1)
+	c.SetWithTTL("b", 2, time.Hour)
+	now = now.Add(time.Minute)
+
+	if _, ok := c.Get("a"); ok {
+		t.Error("a should have expired")
+	}
+	if v, ok := c.Get("b"); !ok || v != 2 {
+		t.Errorf("Get(b) = %d, %v", v, ok)
+	}
+
+	// When full, the entry closest to expiring goes
+	c.Set("c", 3)
+	c.Set("d", 4)
+	if _, ok := c.Get("c"); ok {
+		t.Error("c should have been evicted")
+	}
+	if c.Len() != 2 {
+		t.Errorf("Len = %d", c.Len())
+	}
+
+	// Setting again renews the time to live
+	now = now.Add(50 * time.Second)
+	c.Set("d", 40)
+	now = now.Add(50 * time.Second)
+	if v, ok := c.Get("d"); !ok || v != 40 {
+		t.Errorf("Get(d) = %d, %v", v, ok)
+	}
+
+	now = now.Add(time.Hour)
+	if n := c.Purge(); n != 2 || c.Len() != 0 {
+		t.Errorf("Purge = %d, Len = %d", n, c.Len())
+	}
+	want := Stats{Hits: 2, Misses: 2, Evictions: 1, Expirations: 3}
+	if got := c.Stats(); got != want {
+		t.Errorf("Stats = %+v, want %+v", got, want)
+	}
+
+	unbounded := NewTTL[int, int](time.Minute, 0)
+	for i := range 1000 {
+		unbounded.Set(i, i)
+	}
+	if unbounded.Len() != 1000 || !unbounded.Delete(500) || unbounded.Delete(500) {
+		t.Error("unbounded cache")
+	}
+}
+
+func TestGetOrLoad(t *testing.T) {
+	s := NewSync[string, int](NewLRU[string, int](10))
+
+	var (
+		wg      sync.WaitGroup
+		calls   atomic.Int32
+		release = make(chan struct{})
+	)
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := s.GetOrLoad("key", func(k string) (int, error) {
+				calls.Add(1)
+				<-release
+				return len(k), nil
+			})
+			if v != 3 || err != nil {
+				t.Errorf("GetOrLoad = %d, %v", v, err)
+			}
+		}()
+	}
+	// Let every goroutine reach the cache before the load returns
+	for s.Loads() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 || s.Loads() != 1 {
+		t.Errorf("the loader ran %d times", calls.Load())
+	}
+	if v, ok := s.Get("key"); !ok || v != 3 {
+		t.Errorf("the loaded value is not cached: %d, %v", v, ok)
+	}
+}
+
+func TestGetOrLoadFailures(t *testing.T) {
+	s := NewSync[string, int](NewLRU[string, int](10))
+
+	failure := errors.New("failure")
+	if _, err := s.GetOrLoad("a", func(string) (int, error) { return 0, failure }); !errors.Is(err, failure) {
+		t.Errorf("GetOrLoad = %v", err)
+	}
+	_, err := s.GetOrLoad("a", func(string) (int, error) { panic("boom") })
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("a panic should be returned as an error, got %v", err)
+	}
+	if s.Len() != 0 {
+		t.Error("failed loads should not be stored")
+	}
+
+	v, err := s.GetOrLoad("a", func(string) (int, error) { return 1, nil })
+	if v != 1 || err != nil || s.Loads() != 3 {
+		t.Errorf("GetOrLoad = %d, %v after %d loads", v, err, s.Loads())
+	}
+	s.Set("b", 2)
+	if !s.Delete("b") || s.Stats().Misses != 3 {
+		t.Errorf("Stats = %+v", s.Stats())
+	}
+}
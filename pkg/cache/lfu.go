@@ -0,0 +1,132 @@
+package cache
+
+import "container/list"
+
+// LFU evicts the least frequently used entry when it is full,
+// the least recently used one among those used equally often.
+// Every operation runs in constant time thanks to one list of entries per use count
+type LFU[K comparable, V any] struct {
+	capacity int
+	items    map[K]*list.Element
+	freqs    map[int]*list.List // Entries by use count, most recently used at the front
+	minFreq  int
+	stats    Stats
+}
+
+type lfuEntry[K comparable, V any] struct {
+	key   K
+	value V
+	freq  int
+}
+
+// NewLFU returns an empty LFU cache holding at most "capacity" entries, at least one
+func NewLFU[K comparable, V any](capacity int) *LFU[K, V] {
+	return &LFU[K, V]{
+		capacity: max(capacity, 1),
+		items:    make(map[K]*list.Element),
+		freqs:    make(map[int]*list.List),
+	}
+}
+
+// Get returns the value stored for "key" and counts one more use of it
+func (c *LFU[K, V]) Get(key K) (V, bool) {
+	e, ok := c.items[key]
+	c.stats.record(ok)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	c.touch(e)
+	return e.Value.(*lfuEntry[K, V]).value, true
+}
+
+// Set stores "value" for "key", evicting the least frequently used entry when full.
+// Updating an existing key counts as a use
+func (c *LFU[K, V]) Set(key K, value V) {
+	if e, ok := c.items[key]; ok {
+		e.Value.(*lfuEntry[K, V]).value = value
+		c.touch(e)
+		return
+	}
+
+	if len(c.items) >= c.capacity {
+		victims, ok := c.freqs[c.minFreq]
+		if !ok {
+			// The lowest count is stale after a Delete emptied its list
+			c.minFreq = c.lowestFreq()
+			victims = c.freqs[c.minFreq]
+		}
+		oldest := victims.Back()
+		c.unlink(oldest)
+		delete(c.items, oldest.Value.(*lfuEntry[K, V]).key)
+		c.stats.Evictions++
+	}
+
+	// A new entry has been used once, which is now the lowest count
+	c.minFreq = 1
+	c.items[key] = c.bucket(1).PushFront(&lfuEntry[K, V]{key: key, value: value, freq: 1})
+}
+
+// Delete removes "key", reporting whether it was present
+func (c *LFU[K, V]) Delete(key K) bool {
+	e, ok := c.items[key]
+	if ok {
+		c.unlink(e)
+		delete(c.items, key)
+	}
+	return ok
+}
+
+// Len returns the number of entries currently stored
+func (c *LFU[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Stats returns the counters collected since the cache was created
+func (c *LFU[K, V]) Stats() Stats {
+	return c.stats
+}
+
+// touch moves an entry to the list of the next use count
+func (c *LFU[K, V]) touch(e *list.Element) {
+	entry := e.Value.(*lfuEntry[K, V])
+	freq := entry.freq
+	c.unlink(e)
+	if freq == c.minFreq && c.freqs[freq] == nil {
+		c.minFreq++
+	}
+
+	entry.freq++
+	c.items[entry.key] = c.bucket(entry.freq).PushFront(entry)
+}
+
+// unlink removes an entry from its list, dropping the list once empty
+func (c *LFU[K, V]) unlink(e *list.Element) {
+	freq := e.Value.(*lfuEntry[K, V]).freq
+	l := c.freqs[freq]
+	l.Remove(e)
+	if l.Len() == 0 {
+		delete(c.freqs, freq)
+	}
+}
+
+func (c *LFU[K, V]) bucket(freq int) *list.List {
+	l, ok := c.freqs[freq]
+	if !ok {
+		l = list.New()
+		c.freqs[freq] = l
+	}
+	return l
+}
+
+// lowestFreq scans for the lowest use count still in the cache
+func (c *LFU[K, V]) lowestFreq() int {
+	lowest := 0
+	for freq := range c.freqs {
+		if lowest == 0 || freq < lowest {
+			lowest = freq
+		}
+	}
+	return lowest
+}
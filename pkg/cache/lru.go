@@ -0,0 +1,85 @@
+package cache
+
+import "container/list"
+
+// LRU evicts the least recently used entry when it is full.
+// Both Get and Set count as a use
+type LRU[K comparable, V any] struct {
+	capacity int
+	items    map[K]*list.Element
+	order    *list.List // Most recently used at the front
+	stats    Stats
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewLRU returns an empty LRU cache holding at most "capacity" entries, at least one
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	return &LRU[K, V]{
+		capacity: max(capacity, 1),
+		items:    make(map[K]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get returns the value stored for "key" and marks it as recently used
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	e, ok := c.items[key]
+	c.stats.record(ok)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	c.order.MoveToFront(e)
+	return e.Value.(*lruEntry[K, V]).value, true
+}
+
+// Set stores "value" for "key", evicting the least recently used entry when full
+func (c *LRU[K, V]) Set(key K, value V) {
+	if e, ok := c.items[key]; ok {
+		e.Value.(*lruEntry[K, V]).value = value
+		c.order.MoveToFront(e)
+		return
+	}
+
+	if len(c.items) >= c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry[K, V]).key)
+		c.stats.Evictions++
+	}
+	c.items[key] = c.order.PushFront(&lruEntry[K, V]{key, value})
+}
+
+// Delete removes "key", reporting whether it was present
+func (c *LRU[K, V]) Delete(key K) bool {
+	e, ok := c.items[key]
+	if ok {
+		c.order.Remove(e)
+		delete(c.items, key)
+	}
+	return ok
+}
+
+// Len returns the number of entries currently stored
+func (c *LRU[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Stats returns the counters collected since the cache was created
+func (c *LRU[K, V]) Stats() Stats {
+	return c.stats
+}
+
+// Keys returns the keys from the most to the least recently used
+func (c *LRU[K, V]) Keys() []K {
+	keys := make([]K, 0, len(c.items))
+	for e := c.order.Front(); e != nil; e = e.Next() {
+		keys = append(keys, e.Value.(*lruEntry[K, V]).key)
+	}
+	return keys
+}
@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Sync makes a Cache safe for concurrent use and adds GetOrLoad
+type Sync[K comparable, V any] struct {
+	mu    sync.Mutex
+	cache Cache[K, V]
+	calls map[K]*call[V]
+	loads uint64
+}
+
+// call is a load in flight, shared by every goroutine asking for the same key
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// NewSync wraps "cache", which must not be used directly afterwards
+func NewSync[K comparable, V any](cache Cache[K, V]) *Sync[K, V] {
+	return &Sync[K, V]{cache: cache, calls: make(map[K]*call[V])}
+}
+
+// Get returns the value stored for "key" and whether it was found
+func (s *Sync[K, V]) Get(key K) (V, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Get(key)
+}
+
+// Set stores "value" for "key"
+func (s *Sync[K, V]) Set(key K, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache.Set(key, value)
+}
+
+// Delete removes "key", reporting whether it was present
+func (s *Sync[K, V]) Delete(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Delete(key)
+}
+
+// Len returns the number of entries currently stored
+func (s *Sync[K, V]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Len()
+}
+
+// Stats returns the counters of the wrapped cache
+func (s *Sync[K, V]) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Stats()
+}
+
+// Loads returns how many times a loader passed to GetOrLoad actually ran
+func (s *Sync[K, V]) Loads() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.loads
+}
+
+// GetOrLoad returns the value stored for "key", calling "load" to produce it when missing.
+// Concurrent calls for the same missing key wait for a single call of "load"
+// and all get its result. Successful results are stored, errors are not,
+// and a panic in "load" is returned as an error to every waiting caller
+func (s *Sync[K, V]) GetOrLoad(key K, load func(K) (V, error)) (V, error) {
+	s.mu.Lock()
+	if v, ok := s.cache.Get(key); ok {
+		s.mu.Unlock()
+		return v, nil
+	}
+	if c, ok := s.calls[key]; ok {
+		s.mu.Unlock()
+		<-c.done
+		return c.value, c.err
+	}
+
+	c := &call[V]{done: make(chan struct{})}
+	s.calls[key] = c
+	s.loads++
+	s.mu.Unlock()
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				c.err = fmt.Errorf("cache: load panicked: %v", r)
+			}
+		}()
+		c.value, c.err = load(key)
+	}()
+
+	s.mu.Lock()
+	if c.err == nil {
+		s.cache.Set(key, c.value)
+	}
+	delete(s.calls, key)
+	s.mu.Unlock()
+
+	close(c.done)
+	return c.value, c.err
+}
+
+var (
+	_ Cache[string, int] = (*LRU[string, int])(nil)
+	_ Cache[string, int] = (*LFU[string, int])(nil)
+	_ Cache[string, int] = (*TTL[string, int])(nil)
+	_ Cache[string, int] = (*Sync[string, int])(nil)
+)
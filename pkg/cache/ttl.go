@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"container/heap"
+	"time"
+)
+
+// TTL drops entries once they are older than their time to live.
+// Expired entries are removed lazily, when they are looked up or when room is needed,
+// and when the cache is full the entry closest to expiring is evicted
+type TTL[K comparable, V any] struct {
+	ttl      time.Duration
+	capacity int
+	items    map[K]*ttlEntry[K, V]
+	expiry   ttlHeap[K, V]
+	stats    Stats
+
+	// Now returns the current time, it can be replaced to control the clock in tests
+	Now func() time.Time
+}
+
+type ttlEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+	index   int // Position in the heap
+}
+
+// NewTTL returns an empty cache whose entries live for "ttl".
+// A "capacity" of zero or less means the cache is not bounded in size
+func NewTTL[K comparable, V any](ttl time.Duration, capacity int) *TTL[K, V] {
+	return &TTL[K, V]{
+		ttl:      ttl,
+		capacity: capacity,
+		items:    make(map[K]*ttlEntry[K, V]),
+		Now:      time.Now,
+	}
+}
+
+// Get returns the value stored for "key" unless it has expired
+func (c *TTL[K, V]) Get(key K) (V, bool) {
+	e, ok := c.items[key]
+	if ok && !c.Now().Before(e.expires) {
+		c.remove(e)
+		c.stats.Expirations++
+		ok = false
+	}
+
+	c.stats.record(ok)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Set stores "value" for "key" with the default time to live
+func (c *TTL[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL stores "value" for "key", expiring after "ttl" instead of the default
+func (c *TTL[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	expires := c.Now().Add(ttl)
+
+	if e, ok := c.items[key]; ok {
+		e.value, e.expires = value, expires
+		heap.Fix(&c.expiry, e.index)
+		return
+	}
+
+	if c.capacity > 0 && len(c.items) >= c.capacity {
+		c.purge()
+	}
+	if c.capacity > 0 && len(c.items) >= c.capacity {
+		c.remove(c.expiry[0])
+		c.stats.Evictions++
+	}
+
+	e := &ttlEntry[K, V]{key: key, value: value, expires: expires}
+	c.items[key] = e
+	heap.Push(&c.expiry, e)
+}
+
+// Delete removes "key", reporting whether it was present
+func (c *TTL[K, V]) Delete(key K) bool {
+	e, ok := c.items[key]
+	if ok {
+		c.remove(e)
+	}
+	return ok
+}
+
+// Len returns the number of entries stored, including expired ones not removed yet
+func (c *TTL[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Stats returns the counters collected since the cache was created
+func (c *TTL[K, V]) Stats() Stats {
+	return c.stats
+}
+
+// Purge removes every expired entry and returns how many there were
+func (c *TTL[K, V]) Purge() int {
+	return c.purge()
+}
+
+func (c *TTL[K, V]) purge() int {
+	now := c.Now()
+	n := 0
+	for len(c.expiry) > 0 && !now.Before(c.expiry[0].expires) {
+		c.remove(c.expiry[0])
+		c.stats.Expirations++
+		n++
+	}
+	return n
+}
+
+func (c *TTL[K, V]) remove(e *ttlEntry[K, V]) {
+	heap.Remove(&c.expiry, e.index)
+	delete(c.items, e.key)
+}
+
+// ttlHeap implements "heap.Interface", the entry expiring first at the root
+type ttlHeap[K comparable, V any] []*ttlEntry[K, V]
+
+func (h ttlHeap[K, V]) Len() int {
+	return len(h)
+}
+
+func (h ttlHeap[K, V]) Less(i, j int) bool {
+	return h[i].expires.Before(h[j].expires)
+}
+
+func (h ttlHeap[K, V]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *ttlHeap[K, V]) Push(x any) {
+	e := x.(*ttlEntry[K, V])
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *ttlHeap[K, V]) Pop() any {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return e
+}
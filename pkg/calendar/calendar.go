@@ -0,0 +1,274 @@
+// Package calendar answers business-day questions that the switch example answers
+// with "time.Saturday, time.Sunday" and "t.Hour() < 12": which days are worked,
+// given configurable weekends and holidays loaded from a file,
+// how to add working days to a date, and how many working hours separate two instants.
+//
+// Every instant is looked at in the location of the calendar,
+// so instants from any time zone can be mixed freely.
+package calendar
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Date is a day of the civil calendar, without time of day or location
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date of "t" in its own location
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{y, m, d}
+}
+
+// In returns the instant at "clock" past midnight of the date in "loc".
+// The clock time is kept even on days when daylight saving time changes
+func (d Date) In(loc *time.Location, clock time.Duration) time.Time {
+	h := int(clock / time.Hour)
+	m := int(clock % time.Hour / time.Minute)
+	s := int(clock % time.Minute / time.Second)
+	ns := int(clock % time.Second)
+	return time.Date(d.Year, d.Month, d.Day, h, m, s, ns, loc)
+}
+
+// AddDays returns the date "n" days later, normalizing month and year
+func (d Date) AddDays(n int) Date {
+	return DateOf(time.Date(d.Year, d.Month, d.Day+n, 12, 0, 0, 0, time.UTC))
+}
+
+// Weekday returns the day of the week of the date
+func (d Date) Weekday() time.Weekday {
+	return time.Date(d.Year, d.Month, d.Day, 12, 0, 0, 0, time.UTC).Weekday()
+}
+
+// Before reports whether "d" comes before "e"
+func (d Date) Before(e Date) bool {
+	if d.Year != e.Year {
+		return d.Year < e.Year
+	}
+	if d.Month != e.Month {
+		return d.Month < e.Month
+	}
+	return d.Day < e.Day
+}
+
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// Holiday is a named day off
+type Holiday struct {
+	Date Date
+	Name string
+}
+
+// Calendar knows the weekend days, the holidays and the business hours of a place
+type Calendar struct {
+	loc        *time.Location
+	weekend    [7]bool
+	holidays   map[Date]string
+	start, end time.Duration
+}
+
+// Option configures a Calendar built by New
+type Option func(*Calendar)
+
+// WithWeekend replaces the default weekend of Saturday and Sunday
+func WithWeekend(days ...time.Weekday) Option {
+	return func(c *Calendar) {
+		c.weekend = [7]bool{}
+		for _, d := range days {
+			c.weekend[d] = true
+		}
+	}
+}
+
+// WithLocation sets the time zone in which days and hours are counted, UTC by default
+func WithLocation(loc *time.Location) Option {
+	return func(c *Calendar) {
+		c.loc = loc
+	}
+}
+
+// WithBusinessHours sets the working hours of a business day as offsets from midnight,
+// 9:00 to 17:00 by default
+func WithBusinessHours(start, end time.Duration) Option {
+	return func(c *Calendar) {
+		c.start, c.end = start, end
+	}
+}
+
+// WithHolidays adds holidays to the calendar
+func WithHolidays(holidays ...Holiday) Option {
+	return func(c *Calendar) {
+		for _, h := range holidays {
+			c.holidays[h.Date] = h.Name
+		}
+	}
+}
+
+// New returns a calendar with the given options applied over the defaults
+func New(opts ...Option) *Calendar {
+	c := &Calendar{
+		loc:      time.UTC,
+		holidays: make(map[Date]string),
+		start:    9 * time.Hour,
+		end:      17 * time.Hour,
+	}
+	c.weekend[time.Saturday] = true
+	c.weekend[time.Sunday] = true
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Location returns the time zone of the calendar
+func (c *Calendar) Location() *time.Location {
+	return c.loc
+}
+
+// AddHoliday marks the date as a holiday
+func (c *Calendar) AddHoliday(d Date, name string) {
+	c.holidays[d] = name
+}
+
+// Holidays returns every holiday, sorted by date
+func (c *Calendar) Holidays() []Holiday {
+	list := make([]Holiday, 0, len(c.holidays))
+	for d, name := range c.holidays {
+		list = append(list, Holiday{d, name})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Date.Before(list[j].Date) })
+	return list
+}
+
+// date returns the date of "t" in the location of the calendar
+func (c *Calendar) date(t time.Time) Date {
+	return DateOf(t.In(c.loc))
+}
+
+// IsWeekend reports whether "t" falls on a weekend day
+func (c *Calendar) IsWeekend(t time.Time) bool {
+	return c.weekend[t.In(c.loc).Weekday()]
+}
+
+// Holiday returns the name of the holiday "t" falls on, if any
+func (c *Calendar) Holiday(t time.Time) (string, bool) {
+	name, ok := c.holidays[c.date(t)]
+	return name, ok
+}
+
+// IsBusinessDay reports whether "t" falls on a day that is neither a weekend day nor a holiday
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	return c.isBusinessDate(c.date(t))
+}
+
+func (c *Calendar) isBusinessDate(d Date) bool {
+	if c.weekend[d.Weekday()] {
+		return false
+	}
+	_, holiday := c.holidays[d]
+	return !holiday
+}
+
+// AddBusinessDays moves "t" by "n" business days, backwards when "n" is negative,
+// keeping its clock time in the calendar location.
+// Starting from a day off, the first step lands on the nearest business day
+func (c *Calendar) AddBusinessDays(t time.Time, n int) time.Time {
+	local := t.In(c.loc)
+	d := DateOf(local)
+	// Read the clock from the wall time: the elapsed time since midnight
+	// is an hour off after a daylight saving change
+	clock := time.Duration(local.Hour())*time.Hour +
+		time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second +
+		time.Duration(local.Nanosecond())
+
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	if c.weekend == [7]bool{true, true, true, true, true, true, true} {
+		panic("calendar: every day of the week is a weekend day")
+	}
+	for n > 0 {
+		d = d.AddDays(step)
+		if c.isBusinessDate(d) {
+			n--
+		}
+	}
+	return d.In(c.loc, clock)
+}
+
+// BusinessDaysBetween counts the business days from the date of "a" included
+// to the date of "b" excluded, negative when "b" is before "a"
+func (c *Calendar) BusinessDaysBetween(a, b time.Time) int {
+	da, db := c.date(a), c.date(b)
+	sign := 1
+	if db.Before(da) {
+		da, db, sign = db, da, -1
+	}
+
+	count := 0
+	for d := da; d.Before(db); d = d.AddDays(1) {
+		if c.isBusinessDate(d) {
+			count++
+		}
+	}
+	return sign * count
+}
+
+// BusinessHoursBetween returns how much of the interval from "a" to "b"
+// falls within business hours of business days, negative when "b" is before "a"
+func (c *Calendar) BusinessHoursBetween(a, b time.Time) time.Duration {
+	sign := time.Duration(1)
+	if b.Before(a) {
+		a, b, sign = b, a, -1
+	}
+
+	var total time.Duration
+	last := c.date(b)
+	for d := c.date(a); !last.Before(d); d = d.AddDays(1) {
+		if !c.isBusinessDate(d) {
+			continue
+		}
+
+		// Overlap of [a, b] with the working hours of the day
+		open, shut := d.In(c.loc, c.start), d.In(c.loc, c.end)
+		from, to := laterOf(open, a), earlierOf(shut, b)
+		if to.After(from) {
+			total += to.Sub(from)
+		}
+	}
+	return sign * total
+}
+
+// IsBusinessHour reports whether "t" is within business hours of a business day
+func (c *Calendar) IsBusinessHour(t time.Time) bool {
+	d := c.date(t)
+	if !c.isBusinessDate(d) {
+		return false
+	}
+	return !t.Before(d.In(c.loc, c.start)) && t.Before(d.In(c.loc, c.end))
+}
+
+func laterOf(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlierOf(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
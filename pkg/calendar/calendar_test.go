@@ -0,0 +1,210 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func newYork(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no time zone database:", err)
+	}
+	return loc
+}
+
+func TestDate(t *testing.T) {
+	d := Date{2026, time.December, 31}
+	if got := d.AddDays(1); got != (Date{2027, time.January, 1}) {
+		t.Errorf("AddDays(1) = %v", got)
+	}
+	if got := d.AddDays(-365); got != (Date{2025, time.December, 31}) {
+		t.Errorf("AddDays(-365) = %v", got)
+	}
+	if d.Weekday() != time.Thursday || d.String() != "2026-12-31" {
+		t.Errorf("%v is a %v", d, d.Weekday())
+	}
+	if !d.Before(d.AddDays(1)) || d.Before(d) {
+		t.Error("Before")
+	}
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	c := New(WithHolidays(Holiday{Date{2026, time.December, 25}, "Christmas"}))
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, time.December, day, hour, 30, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		from time.Time
+		n    int
+		want time.Time
+	}{
+		{at(21, 10), 0, at(21, 10)},
+		{at(21, 10), 1, at(22, 10)},
+		{at(24, 10), 1, at(28, 10)},  // over Christmas and the weekend
+		{at(28, 10), -1, at(24, 10)}, // and back
+		{at(26, 10), 1, at(28, 10)},  // from a Saturday
+		{at(26, 10), -1, at(24, 10)},
+		{at(21, 23), 10, time.Date(2027, time.January, 5, 23, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := c.AddBusinessDays(tt.from, tt.n); !got.Equal(tt.want) {
+			t.Errorf("AddBusinessDays(%v, %d) = %v, want %v", tt.from, tt.n, got, tt.want)
+		}
+	}
+}
+
+// TestAddBusinessDaysDST starts and ends on the days daylight saving time begins and ends,
+// when midnight and the clock time are not the usual distance apart
+func TestAddBusinessDaysDST(t *testing.T) {
+	loc := newYork(t)
+	everyDay := New(WithLocation(loc), WithWeekend())
+	weekdays := New(WithLocation(loc))
+	at := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 10, 15, 30, 500, loc)
+	}
+
+	tests := []struct {
+		name string
+		c    *Calendar
+		from time.Time
+		n    int
+		want time.Time
+	}{
+		{"from spring forward", everyDay, at(time.March, 8), 1, at(time.March, 9)},
+		{"onto spring forward", everyDay, at(time.March, 7), 1, at(time.March, 8)},
+		{"back from spring forward", everyDay, at(time.March, 8), -1, at(time.March, 7)},
+		{"from fall back", everyDay, at(time.November, 1), 1, at(time.November, 2)},
+		{"onto fall back", everyDay, at(time.November, 2), -1, at(time.November, 1)},
+		{"weekend with spring forward", weekdays, at(time.March, 8), 1, at(time.March, 9)},
+		{"weekend with fall back", weekdays, at(time.November, 1), -1, at(time.October, 30)},
+		{"over spring forward", weekdays, at(time.March, 6), 1, at(time.March, 9)},
+		{"over fall back", weekdays, at(time.October, 30), 1, at(time.November, 2)},
+	}
+	for _, tt := range tests {
+		got := tt.c.AddBusinessDays(tt.from, tt.n)
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: AddBusinessDays(%v, %d) = %v, want %v", tt.name, tt.from, tt.n, got, tt.want)
+		}
+	}
+
+	// The instant is given in another zone, the clock time is kept in the calendar location
+	from := at(time.March, 8).UTC()
+	if got := everyDay.AddBusinessDays(from, 1).In(loc); got.Hour() != 10 || got.Minute() != 15 {
+		t.Errorf("from UTC: got %v", got)
+	}
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+	c := New(WithHolidays(Holiday{Date{2026, time.December, 25}, "Christmas"}))
+	a := time.Date(2026, time.December, 21, 0, 0, 0, 0, time.UTC)
+	b := time.Date(2027, time.January, 4, 0, 0, 0, 0, time.UTC)
+	if got := c.BusinessDaysBetween(a, b); got != 9 {
+		t.Errorf("BusinessDaysBetween = %d, want 9", got)
+	}
+	if got := c.BusinessDaysBetween(b, a); got != -9 {
+		t.Errorf("reversed = %d, want -9", got)
+	}
+	if got := c.BusinessDaysBetween(a, a); got != 0 {
+		t.Errorf("same day = %d", got)
+	}
+}
+
+func TestBusinessHours(t *testing.T) {
+	loc := newYork(t)
+	c := New(WithLocation(loc), WithWeekend(), WithBusinessHours(8*time.Hour, 12*time.Hour))
+
+	// 2026-03-08 02:00 does not exist in New York, the day is 23 hours long,
+	// but the morning from 8:00 to 12:00 is still 4 hours
+	a := time.Date(2026, time.March, 7, 11, 0, 0, 0, loc)
+	b := time.Date(2026, time.March, 9, 9, 0, 0, 0, loc)
+	if got := c.BusinessHoursBetween(a, b); got != 6*time.Hour {
+		t.Errorf("BusinessHoursBetween = %v, want 6h", got)
+	}
+	if got := c.BusinessHoursBetween(b, a); got != -6*time.Hour {
+		t.Errorf("reversed = %v, want -6h", got)
+	}
+
+	open := time.Date(2026, time.November, 1, 8, 0, 0, 0, loc)
+	for _, tt := range []struct {
+		t    time.Time
+		want bool
+	}{
+		{open, true},
+		{open.Add(-time.Nanosecond), false},
+		{open.Add(4*time.Hour - time.Nanosecond), true},
+		{open.Add(4 * time.Hour), false},
+		{open.UTC(), true},
+	} {
+		if got := c.IsBusinessHour(tt.t); got != tt.want {
+			t.Errorf("IsBusinessHour(%v) = %v", tt.t, got)
+		}
+	}
+}
+
+func TestParseHolidays(t *testing.T) {
+	list := `# Holidays
+2026-01-01 New Year's Day
+
+2026-12-25 Christmas Day
+`
+	ical := "\ufeffBEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20261224\r\n" +
+		"DTEND;VALUE=DATE:20261227\r\n" +
+		"SUMMARY:Christmas\\, long\r\n" +
+		"  weekend\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;TZID=Asia/Tokyo:20261231T230000\r\n" +
+		"SUMMARY:New Year's Eve\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	tests := []struct {
+		name  string
+		input string
+		want  []Holiday
+	}{
+		{"list", list, []Holiday{
+			{Date{2026, time.January, 1}, "New Year's Day"},
+			{Date{2026, time.December, 25}, "Christmas Day"},
+		}},
+		{"iCalendar", ical, []Holiday{
+			{Date{2026, time.December, 24}, "Christmas, long weekend"},
+			{Date{2026, time.December, 25}, "Christmas, long weekend"},
+			{Date{2026, time.December, 26}, "Christmas, long weekend"},
+			{Date{2026, time.December, 31}, "New Year's Eve"},
+		}},
+	}
+	for _, tt := range tests {
+		got, err := ParseHolidays(strings.NewReader(tt.input))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: holiday %d = %v, want %v", tt.name, i, got[i], tt.want[i])
+			}
+		}
+	}
+
+	for _, bad := range []string{
+		"26-12-25 Christmas",
+		"BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:x\nEND:VEVENT\n",
+		"BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:2026\nEND:VEVENT\n",
+		"BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20260101\n",
+	} {
+		if _, err := ParseHolidays(strings.NewReader(bad)); err == nil {
+			t.Errorf("ParseHolidays(%q) should fail", bad)
+		}
+	}
+}
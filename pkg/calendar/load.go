@@ -0,0 +1,196 @@
+package calendar
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// LoadHolidays reads holidays from a file, see ParseHolidays
+func LoadHolidays(path string) ([]Holiday, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseHolidays(f)
+}
+
+// ParseHolidays reads holidays in either of two formats, told apart by their first line.
+//
+// An iCalendar file, as exported by most calendar applications, starts with "BEGIN:VCALENDAR".
+// Every VEVENT is a holiday named after its SUMMARY, from DTSTART included to DTEND excluded.
+// Recurrence rules are not expanded.
+//
+// Anything else is read as a plain list, one "YYYY-MM-DD Name" per line,
+// where blank lines and lines starting with "#" are ignored
+func ParseHolidays(r io.Reader) ([]Holiday, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("BEGIN:VCALENDAR")) {
+		return parseICal(data)
+	}
+	return parseList(data)
+}
+
+// LoadHolidays adds the holidays of a file to the calendar
+func (c *Calendar) LoadHolidays(path string) error {
+	holidays, err := LoadHolidays(path)
+	if err != nil {
+		return err
+	}
+	WithHolidays(holidays...)(c)
+	return nil
+}
+
+func parseList(data []byte) ([]Holiday, error) {
+	var holidays []Holiday
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		day, name, _ := strings.Cut(text, " ")
+		t, err := time.Parse(time.DateOnly, day)
+		if err != nil {
+			return nil, fmt.Errorf("calendar: line %d: invalid date %q, want YYYY-MM-DD", line, day)
+		}
+		holidays = append(holidays, Holiday{DateOf(t), strings.TrimSpace(name)})
+	}
+	return holidays, sc.Err()
+}
+
+func parseICal(data []byte) ([]Holiday, error) {
+	var (
+		holidays []Holiday
+		inEvent  bool
+		start    *Date
+		end      *Date
+		summary  string
+	)
+	for _, line := range unfold(data) {
+		name, params, value := splitProperty(line.text)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			inEvent, start, end, summary = true, nil, nil, ""
+
+		case name == "END" && value == "VEVENT":
+			if !inEvent {
+				return nil, fmt.Errorf("calendar: line %d: END:VEVENT without BEGIN", line.number)
+			}
+			inEvent = false
+			if start == nil {
+				return nil, fmt.Errorf("calendar: line %d: event %q has no DTSTART", line.number, summary)
+			}
+
+			// An all-day event lasts one day unless DTEND says otherwise
+			last := *start
+			if end != nil && start.Before(*end) {
+				last = end.AddDays(-1)
+			}
+			for d := *start; !last.Before(d); d = d.AddDays(1) {
+				holidays = append(holidays, Holiday{d, summary})
+			}
+
+		case !inEvent:
+
+		case name == "DTSTART" || name == "DTEND":
+			d, err := parseICalDate(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("calendar: line %d: %s: %w", line.number, name, err)
+			}
+			if name == "DTSTART" {
+				start = &d
+			} else {
+				end = &d
+			}
+
+		case name == "SUMMARY":
+			summary = unescapeText(value)
+		}
+	}
+	if inEvent {
+		return nil, errors.New("calendar: unterminated VEVENT")
+	}
+	return holidays, nil
+}
+
+type icalLine struct {
+	number int
+	text   string
+}
+
+// unfold joins the continuation lines of iCalendar, which start with a space or a tab,
+// to the line they continue
+func unfold(data []byte) []icalLine {
+	var lines []icalLine
+	for i, raw := range strings.Split(string(data), "\n") {
+		raw = strings.TrimRight(raw, "\r")
+		if len(raw) > 0 && (raw[0] == ' ' || raw[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1].text += raw[1:]
+			continue
+		}
+		if raw != "" {
+			lines = append(lines, icalLine{i + 1, raw})
+		}
+	}
+	return lines
+}
+
+// splitProperty splits "NAME;PARAM=X;PARAM=Y:value" into its parts
+func splitProperty(line string) (name string, params map[string]string, value string) {
+	head, value, _ := strings.Cut(line, ":")
+	parts := strings.Split(head, ";")
+	params = make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(p, "=")
+		params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, value
+}
+
+// parseICalDate reads a DATE such as "20261225" or a DATE-TIME such as "20261225T090000Z",
+// in which case the date is taken in the time zone of the value
+func parseICalDate(value string, params map[string]string) (Date, error) {
+	if len(value) == 8 {
+		t, err := time.Parse("20060102", value)
+		if err != nil {
+			return Date{}, fmt.Errorf("invalid date %q", value)
+		}
+		return DateOf(t), nil
+	}
+
+	loc := time.UTC
+	if tzid, ok := params["TZID"]; ok {
+		l, err := time.LoadLocation(tzid)
+		if err != nil {
+			return Date{}, err
+		}
+		loc = l
+	}
+	if utc, ok := strings.CutSuffix(value, "Z"); ok {
+		value, loc = utc, time.UTC
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date-time %q", value)
+	}
+	return DateOf(t), nil
+}
+
+// unescapeText undoes the escaping of iCalendar TEXT values
+func unescapeText(s string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
@@ -0,0 +1,72 @@
+package collection
+
+// Keys returns the keys of "m" in no particular order
+func Keys[M ~map[K]V, K comparable, V any](m M) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Values returns the values of "m" in no particular order
+func Values[M ~map[K]V, K comparable, V any](m M) []V {
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+// MapValues returns a map with the same keys as "m" and the values transformed by "f"
+func MapValues[M ~map[K]V, K comparable, V, W any](m M, f func(V) W) map[K]W {
+	out := make(map[K]W, len(m))
+	for k, v := range m {
+		out[k] = f(v)
+	}
+	return out
+}
+
+// FilterMap returns the entries of "m" that satisfy "f"
+func FilterMap[M ~map[K]V, K comparable, V any](m M, f func(K, V) bool) M {
+	out := make(M)
+	for k, v := range m {
+		if f(k, v) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// MergeMaps returns the union of the maps, later maps winning on duplicate keys
+func MergeMaps[M ~map[K]V, K comparable, V any](maps ...M) M {
+	out := make(M)
+	for _, m := range maps {
+		for k, v := range m {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// IntersectKeys returns the entries of "a" whose keys are also in "b"
+func IntersectKeys[M ~map[K]V, N ~map[K]W, K comparable, V, W any](a M, b N) M {
+	out := make(M)
+	for k, v := range a {
+		if _, ok := b[k]; ok {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// DifferenceKeys returns the entries of "a" whose keys are not in "b"
+func DifferenceKeys[M ~map[K]V, N ~map[K]W, K comparable, V, W any](a M, b N) M {
+	out := make(M)
+	for k, v := range a {
+		if _, ok := b[k]; !ok {
+			out[k] = v
+		}
+	}
+	return out
+}
@@ -0,0 +1,83 @@
+package collection
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestMaps(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	keys := Keys(m)
+	slices.Sort(keys)
+	values := Values(m)
+	slices.Sort(values)
+	if !slices.Equal(keys, []string{"a", "b", "c"}) || !slices.Equal(values, []int{1, 2, 3}) {
+		t.Errorf("Keys = %v, Values = %v", keys, values)
+	}
+
+	if got := MapValues(m, func(v int) bool { return v > 1 }); !maps.Equal(got, map[string]bool{"a": false, "b": true, "c": true}) {
+		t.Errorf("MapValues = %v", got)
+	}
+	if got := FilterMap(m, func(k string, v int) bool { return k != "a" && v < 3 }); !maps.Equal(got, map[string]int{"b": 2}) {
+		t.Errorf("FilterMap = %v", got)
+	}
+	if got := MergeMaps(m, map[string]int{"c": 30, "d": 4}); !maps.Equal(got, map[string]int{"a": 1, "b": 2, "c": 30, "d": 4}) {
+		t.Errorf("MergeMaps = %v", got)
+	}
+
+	other := map[string]bool{"b": true, "z": true}
+	if got := IntersectKeys(m, other); !maps.Equal(got, map[string]int{"b": 2}) {
+		t.Errorf("IntersectKeys = %v", got)
+	}
+	if got := DifferenceKeys(m, other); !maps.Equal(got, map[string]int{"a": 1, "c": 3}) {
+		t.Errorf("DifferenceKeys = %v", got)
+	}
+}
+
+var benchMap = func() map[int]int {
+	m := make(map[int]int, 10_000)
+	for i := range 10_000 {
+		m[i] = i * 2
+	}
+	return m
+}()
+
+func BenchmarkKeys(b *testing.B) {
+	b.Run("generic", func(b *testing.B) {
+		for range b.N {
+			sinkInts = Keys(benchMap)
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			keys := make([]int, 0, len(benchMap))
+			for k := range benchMap {
+				keys = append(keys, k)
+			}
+			sinkInts = keys
+		}
+	})
+}
+
+func BenchmarkMergeMaps(b *testing.B) {
+	other := map[int]int{1: 1, 2: 2, 20_000: 3}
+	b.Run("generic", func(b *testing.B) {
+		for range b.N {
+			sinkInt = len(MergeMaps(benchMap, other))
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			out := make(map[int]int)
+			for k, v := range benchMap {
+				out[k] = v
+			}
+			for k, v := range other {
+				out[k] = v
+			}
+			sinkInt = len(out)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package collection
+
+// Union returns the distinct elements found in "a" or "b",
+// in the order they first appear in "a" then "b"
+func Union[T comparable](a, b []T) []T {
+	out := Unique(a)
+	seen := ToSet(out)
+	for _, x := range b {
+		if !seen.Has(x) {
+			seen.Add(x)
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// Intersect returns the distinct elements of "a" that are also in "b", in the order of "a"
+func Intersect[T comparable](a, b []T) []T {
+	in := ToSet(b)
+	return Filter(Unique(a), in.Has)
+}
+
+// Difference returns the distinct elements of "a" that are not in "b", in the order of "a"
+func Difference[T comparable](a, b []T) []T {
+	in := ToSet(b)
+	return Filter(Unique(a), func(x T) bool { return !in.Has(x) })
+}
+
+// Set is an unordered collection of distinct elements
+type Set[T comparable] map[T]struct{}
+
+// ToSet returns the set of the elements of "s"
+func ToSet[T comparable](s []T) Set[T] {
+	set := make(Set[T], len(s))
+	for _, x := range s {
+		set[x] = struct{}{}
+	}
+	return set
+}
+
+// Add inserts "x" into the set
+func (s Set[T]) Add(x T) {
+	s[x] = struct{}{}
+}
+
+// Has reports whether "x" is in the set
+func (s Set[T]) Has(x T) bool {
+	_, ok := s[x]
+	return ok
+}
+
+// Union returns the elements in "s" or "t"
+func (s Set[T]) Union(t Set[T]) Set[T] {
+	out := make(Set[T], max(len(s), len(t)))
+	for x := range s {
+		out[x] = struct{}{}
+	}
+	for x := range t {
+		out[x] = struct{}{}
+	}
+	return out
+}
+
+// Intersect returns the elements in both "s" and "t"
+func (s Set[T]) Intersect(t Set[T]) Set[T] {
+	// Iterate over the smaller set
+	if len(t) < len(s) {
+		s, t = t, s
+	}
+	out := make(Set[T])
+	for x := range s {
+		if t.Has(x) {
+			out[x] = struct{}{}
+		}
+	}
+	return out
+}
+
+// Difference returns the elements of "s" that are not in "t"
+func (s Set[T]) Difference(t Set[T]) Set[T] {
+	out := make(Set[T])
+	for x := range s {
+		if !t.Has(x) {
+			out[x] = struct{}{}
+		}
+	}
+	return out
+}
+
+// SymmetricDifference returns the elements in exactly one of "s" and "t"
+func (s Set[T]) SymmetricDifference(t Set[T]) Set[T] {
+	return s.Difference(t).Union(t.Difference(s))
+}
+
+// Slice returns the elements of the set in no particular order
+func (s Set[T]) Slice() []T {
+	out := make([]T, 0, len(s))
+	for x := range s {
+		out = append(out, x)
+	}
+	return out
+}
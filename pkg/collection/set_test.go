@@ -0,0 +1,93 @@
+package collection
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSliceSets(t *testing.T) {
+	a, b := []int{1, 2, 2, 3}, []int{3, 4, 1, 4}
+	if got := Union(a, b); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("Union = %v", got)
+	}
+	if got := Intersect(a, b); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("Intersect = %v", got)
+	}
+	if got := Difference(a, b); !slices.Equal(got, []int{2}) {
+		t.Errorf("Difference = %v", got)
+	}
+}
+
+func TestSet(t *testing.T) {
+	s, u := ToSet([]int{1, 2, 3}), ToSet([]int{3, 4})
+	sorted := func(s Set[int]) []int {
+		out := s.Slice()
+		slices.Sort(out)
+		return out
+	}
+
+	if got := sorted(s.Union(u)); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("Union = %v", got)
+	}
+	if got := sorted(s.Intersect(u)); !slices.Equal(got, []int{3}) {
+		t.Errorf("Intersect = %v", got)
+	}
+	if got := sorted(s.Difference(u)); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Difference = %v", got)
+	}
+	if got := sorted(s.SymmetricDifference(u)); !slices.Equal(got, []int{1, 2, 4}) {
+		t.Errorf("SymmetricDifference = %v", got)
+	}
+
+	s.Add(9)
+	if !s.Has(9) || s.Has(4) || len(s) != 4 {
+		t.Error("Add/Has")
+	}
+}
+
+func BenchmarkIntersect(b *testing.B) {
+	other := benchInts[len(benchInts)/2:]
+	b.Run("generic", func(b *testing.B) {
+		for range b.N {
+			sinkInts = Intersect(benchInts, other)
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			in := make(map[int]bool, len(other))
+			for _, x := range other {
+				in[x] = true
+			}
+			seen := make(map[int]bool)
+			var out []int
+			for _, x := range benchInts {
+				if in[x] && !seen[x] {
+					seen[x] = true
+					out = append(out, x)
+				}
+			}
+			sinkInts = out
+		}
+	})
+}
+
+func BenchmarkSetUnion(b *testing.B) {
+	s, t := ToSet(benchInts[:5000]), ToSet(benchInts[5000:])
+	b.Run("generic", func(b *testing.B) {
+		for range b.N {
+			sinkInt = len(s.Union(t))
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			out := make(map[int]struct{}, len(s)+len(t))
+			for x := range s {
+				out[x] = struct{}{}
+			}
+			for x := range t {
+				out[x] = struct{}{}
+			}
+			sinkInt = len(out)
+		}
+	})
+}
@@ -0,0 +1,168 @@
+// Package collection provides the "collection functions" of the Go by Example list
+// (Index, Include, Any, All, Filter, Map) for any element type through generics,
+// together with grouping, chunking, zipping and set operations.
+//
+// Functions never modify their input, they return new slices and maps.
+package collection
+
+// Index returns the index of the first occurrence of "v" in "s", or -1 when it is missing
+func Index[T comparable](s []T, v T) int {
+	for i, x := range s {
+		if x == v {
+			return i
+		}
+	}
+	return -1
+}
+
+// Include reports whether "v" is in "s"
+func Include[T comparable](s []T, v T) bool {
+	return Index(s, v) >= 0
+}
+
+// Any reports whether at least one element of "s" satisfies "f"
+func Any[T any](s []T, f func(T) bool) bool {
+	for _, x := range s {
+		if f(x) {
+			return true
+		}
+	}
+	return false
+}
+
+// All reports whether every element of "s" satisfies "f", true for an empty slice
+func All[T any](s []T, f func(T) bool) bool {
+	for _, x := range s {
+		if !f(x) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the elements of "s" that satisfy "f", in order
+func Filter[T any](s []T, f func(T) bool) []T {
+	var out []T
+	for _, x := range s {
+		if f(x) {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// Map returns the results of applying "f" to every element of "s"
+func Map[T, U any](s []T, f func(T) U) []U {
+	out := make([]U, len(s))
+	for i, x := range s {
+		out[i] = f(x)
+	}
+	return out
+}
+
+// Reduce folds "s" from the left, starting with "init"
+func Reduce[T, A any](s []T, init A, f func(acc A, x T) A) A {
+	acc := init
+	for _, x := range s {
+		acc = f(acc, x)
+	}
+	return acc
+}
+
+// GroupBy groups the elements of "s" by the key returned by "key", keeping their order
+func GroupBy[T any, K comparable](s []T, key func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, x := range s {
+		k := key(x)
+		groups[k] = append(groups[k], x)
+	}
+	return groups
+}
+
+// Partition splits "s" into the elements that satisfy "f" and those that do not
+func Partition[T any](s []T, f func(T) bool) (yes, no []T) {
+	for _, x := range s {
+		if f(x) {
+			yes = append(yes, x)
+		} else {
+			no = append(no, x)
+		}
+	}
+	return yes, no
+}
+
+// Chunk splits "s" into consecutive slices of "size" elements, the last one may be shorter.
+// The chunks share the memory of "s". It panics when "size" is not positive
+func Chunk[T any](s []T, size int) [][]T {
+	if size <= 0 {
+		panic("collection: chunk size must be positive")
+	}
+
+	chunks := make([][]T, 0, (len(s)+size-1)/size)
+	for start := 0; start < len(s); start += size {
+		end := min(start+size, len(s))
+		chunks = append(chunks, s[start:end:end])
+	}
+	return chunks
+}
+
+// Pair holds two values of possibly different types
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip pairs the elements of "a" and "b" by index, stopping at the end of the shorter one
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	n := min(len(a), len(b))
+	out := make([]Pair[A, B], n)
+	for i := 0; i < n; i++ {
+		out[i] = Pair[A, B]{a[i], b[i]}
+	}
+	return out
+}
+
+// Unzip splits pairs back into two slices
+func Unzip[A, B any](pairs []Pair[A, B]) ([]A, []B) {
+	a, b := make([]A, len(pairs)), make([]B, len(pairs))
+	for i, p := range pairs {
+		a[i], b[i] = p.First, p.Second
+	}
+	return a, b
+}
+
+// Unique returns the elements of "s" without duplicates, keeping the first occurrences in order
+func Unique[T comparable](s []T) []T {
+	seen := make(map[T]struct{}, len(s))
+	var out []T
+	for _, x := range s {
+		if _, ok := seen[x]; !ok {
+			seen[x] = struct{}{}
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// Flatten concatenates the slices of "s" into one
+func Flatten[T any](s [][]T) []T {
+	n := 0
+	for _, inner := range s {
+		n += len(inner)
+	}
+
+	out := make([]T, 0, n)
+	for _, inner := range s {
+		out = append(out, inner...)
+	}
+	return out
+}
+
+// FlatMap applies "f" to every element of "s" and concatenates the results
+func FlatMap[T, U any](s []T, f func(T) []U) []U {
+	var out []U
+	for _, x := range s {
+		out = append(out, f(x)...)
+	}
+	return out
+}
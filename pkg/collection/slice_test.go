@@ -0,0 +1,222 @@
+package collection
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestSliceFunctions(t *testing.T) {
+	s := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	even := func(x int) bool { return x%2 == 0 }
+
+	if Index(s, 1) != 1 || Index(s, 7) != -1 || !Include(s, 9) || Include(s, 8) {
+		t.Error("Index/Include")
+	}
+	if !Any(s, even) || All(s, even) || !All([]int{}, even) || Any([]int{}, even) {
+		t.Error("Any/All")
+	}
+	if got := Filter(s, even); !slices.Equal(got, []int{4, 2, 6}) {
+		t.Errorf("Filter = %v", got)
+	}
+	if got := Map(s[:3], strconv.Itoa); !slices.Equal(got, []string{"3", "1", "4"}) {
+		t.Errorf("Map = %v", got)
+	}
+	if got := Reduce(s, 0, func(acc, x int) int { return acc + x }); got != 31 {
+		t.Errorf("Reduce = %d", got)
+	}
+	if yes, no := Partition(s, even); !slices.Equal(yes, []int{4, 2, 6}) || !slices.Equal(no, []int{3, 1, 1, 5, 9}) {
+		t.Errorf("Partition = %v, %v", yes, no)
+	}
+	if got := GroupBy(s, even); !slices.Equal(got[true], []int{4, 2, 6}) || len(got) != 2 {
+		t.Errorf("GroupBy = %v", got)
+	}
+	if got := Unique(s); !slices.Equal(got, []int{3, 1, 4, 5, 9, 2, 6}) {
+		t.Errorf("Unique = %v", got)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	chunks := Chunk(s, 2)
+	if len(chunks) != 3 || !slices.Equal(chunks[2], []int{5}) {
+		t.Fatalf("Chunk = %v", chunks)
+	}
+
+	// Appending to a chunk must not overwrite the next one
+	_ = append(chunks[0], 99)
+	if s[2] != 3 {
+		t.Error("a chunk has spare capacity over the next one")
+	}
+	if len(Chunk([]int{}, 3)) != 0 {
+		t.Error("Chunk of an empty slice")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Chunk(0) did not panic")
+		}
+	}()
+	Chunk(s, 0)
+}
+
+func TestZipFlatten(t *testing.T) {
+	pairs := Zip([]int{1, 2, 3}, []string{"a", "b"})
+	if len(pairs) != 2 || pairs[1] != (Pair[int, string]{2, "b"}) {
+		t.Errorf("Zip = %v", pairs)
+	}
+	a, b := Unzip(pairs)
+	if !slices.Equal(a, []int{1, 2}) || !slices.Equal(b, []string{"a", "b"}) {
+		t.Errorf("Unzip = %v, %v", a, b)
+	}
+	if got := Flatten([][]int{{1}, nil, {2, 3}}); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Flatten = %v", got)
+	}
+	if got := FlatMap([]int{1, 2}, func(x int) []int { return []int{x, x * 10} }); !slices.Equal(got, []int{1, 10, 2, 20}) {
+		t.Errorf("FlatMap = %v", got)
+	}
+}
+
+// The benchmarks compare the generic functions with the loops they replace
+
+var (
+	benchInts = func() []int {
+		s := make([]int, 10_000)
+		for i := range s {
+			s[i] = (i * 7919) % 5000
+		}
+		return s
+	}()
+	sinkInts []int
+	sinkInt  int
+	sinkBool bool
+)
+
+func BenchmarkIndex(b *testing.B) {
+	b.Run("generic", func(b *testing.B) {
+		for range b.N {
+			sinkInt = Index(benchInts, -1)
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			sinkInt = -1
+			for i, x := range benchInts {
+				if x == -1 {
+					sinkInt = i
+					break
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkAny(b *testing.B) {
+	b.Run("generic", func(b *testing.B) {
+		for range b.N {
+			sinkBool = Any(benchInts, func(x int) bool { return x < 0 })
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			sinkBool = false
+			for _, x := range benchInts {
+				if x < 0 {
+					sinkBool = true
+					break
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkFilter(b *testing.B) {
+	b.Run("generic", func(b *testing.B) {
+		for range b.N {
+			sinkInts = Filter(benchInts, func(x int) bool { return x%2 == 0 })
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			var out []int
+			for _, x := range benchInts {
+				if x%2 == 0 {
+					out = append(out, x)
+				}
+			}
+			sinkInts = out
+		}
+	})
+}
+
+func BenchmarkMap(b *testing.B) {
+	b.Run("generic", func(b *testing.B) {
+		for range b.N {
+			sinkInts = Map(benchInts, func(x int) int { return x * 2 })
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			out := make([]int, len(benchInts))
+			for i, x := range benchInts {
+				out[i] = x * 2
+			}
+			sinkInts = out
+		}
+	})
+}
+
+func BenchmarkReduce(b *testing.B) {
+	b.Run("generic", func(b *testing.B) {
+		for range b.N {
+			sinkInt = Reduce(benchInts, 0, func(acc, x int) int { return acc + x })
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			sum := 0
+			for _, x := range benchInts {
+				sum += x
+			}
+			sinkInt = sum
+		}
+	})
+}
+
+func BenchmarkGroupBy(b *testing.B) {
+	b.Run("generic", func(b *testing.B) {
+		for range b.N {
+			sinkInt = len(GroupBy(benchInts, func(x int) int { return x % 10 }))
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			groups := make(map[int][]int)
+			for _, x := range benchInts {
+				groups[x%10] = append(groups[x%10], x)
+			}
+			sinkInt = len(groups)
+		}
+	})
+}
+
+func BenchmarkUnique(b *testing.B) {
+	b.Run("generic", func(b *testing.B) {
+		for range b.N {
+			sinkInts = Unique(benchInts)
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for range b.N {
+			seen := make(map[int]bool, len(benchInts))
+			var out []int
+			for _, x := range benchInts {
+				if !seen[x] {
+					seen[x] = true
+					out = append(out, x)
+				}
+			}
+			sinkInts = out
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package convert
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// Slice converts every element of a slice or an array with "elem",
+// e.g. the "[]interface{}" of a decoded JSON array into "[]int" with "Slice(v, Strict.Int)".
+// The error of an element gives its index in its Path
+func Slice[T any](v any, elem func(any) (T, error)) ([]T, error) {
+	rv := value(v)
+	if !rv.IsValid() || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+		return nil, fail(v, "slice", ErrType)
+	}
+	if rv.Kind() == reflect.Slice && rv.IsNil() {
+		return nil, nil
+	}
+
+	out := make([]T, rv.Len())
+	for i := range out {
+		x, err := elem(rv.Index(i).Interface())
+		if err != nil {
+			return nil, within("["+strconv.Itoa(i)+"]", err)
+		}
+		out[i] = x
+	}
+	return out, nil
+}
+
+// Map converts every value of a map with string keys with "elem",
+// e.g. the "map[string]interface{}" of a decoded JSON object into "map[string]time.Duration".
+// The error of a value gives its key in its Path
+func Map[V any](v any, elem func(any) (V, error)) (map[string]V, error) {
+	rv := value(v)
+	if !rv.IsValid() || rv.Kind() != reflect.Map {
+		return nil, fail(v, "map", ErrType)
+	}
+	if rv.Type().Key().Kind() != reflect.String {
+		return nil, fail(v, "map", ErrType)
+	}
+	if rv.IsNil() {
+		return nil, nil
+	}
+
+	out := make(map[string]V, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		key := iter.Key().String()
+		x, err := elem(iter.Value().Interface())
+		if err != nil {
+			return nil, within("."+key, err)
+		}
+		out[key] = x
+	}
+	return out, nil
+}
+
+// within prefixes the path of a conversion error with the location of its container
+func within(location string, err error) error {
+	e, ok := err.(*Error)
+	if !ok {
+		return err
+	}
+
+	path := e.Path
+	if path != "" && path[0] != '[' {
+		path = "." + path
+	}
+	copied := *e
+	copied.Path = location + path
+	if copied.Path[0] == '.' {
+		copied.Path = copied.Path[1:]
+	}
+	return &copied
+}
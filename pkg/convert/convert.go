@@ -0,0 +1,125 @@
+// Package convert turns the untyped values of decoded JSON or configuration,
+// held in "interface{}", into concrete types without the panic of a failed
+// type assertion like "i.(float64)" in the type assertions example.
+//
+// Conversions come in two modes. Strict only accepts values that represent
+// the target exactly, e.g. the float64 "3" of a JSON number as an int but not "3.5".
+// Lossy also parses strings, truncates fractions and maps booleans to numbers.
+// Both modes return an *Error instead of silently producing a wrong value
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// The reasons a conversion fails, found with "errors.Is"
+var (
+	ErrType   = errors.New("unsupported type")
+	ErrLoss   = errors.New("value would lose information")
+	ErrRange  = errors.New("value out of range")
+	ErrSyntax = errors.New("invalid syntax")
+)
+
+// Error describes a failed conversion
+type Error struct {
+	// Path locates the value inside slices and maps, e.g. "[2].port",
+	// it is empty for the converted value itself
+	Path   string
+	Value  any
+	Target string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString("convert: ")
+	if e.Path != "" {
+		b.WriteString(e.Path + ": ")
+	}
+	fmt.Fprintf(&b, "cannot convert %s to %s: %v", describe(e.Value), e.Target, e.Err)
+	return b.String()
+}
+
+// Unwrap returns the reason of the failure, e.g. ErrRange
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// describe renders a value with its type, e.g. `string "abc"` or "float64(3.5)"
+func describe(v any) string {
+	const limit = 40
+	switch v := v.(type) {
+	case nil:
+		return "nil"
+	case string:
+		if len(v) > limit {
+			v = v[:limit] + "..."
+		}
+		return fmt.Sprintf("string %q", v)
+	}
+
+	s := fmt.Sprint(v)
+	if len(s) > limit {
+		s = s[:limit] + "..."
+	}
+	return fmt.Sprintf("%T(%s)", v, s)
+}
+
+// Converter converts values in the strict or the lossy mode
+type Converter struct {
+	Lossy bool
+
+	// TimeLayouts are tried in order by the lossy mode to parse times,
+	// DefaultTimeLayouts when empty. The strict mode only accepts RFC 3339
+	TimeLayouts []string
+
+	// Location is the time zone of parsed times that do not give one, UTC when nil
+	Location *time.Location
+}
+
+// The two modes with their default settings
+var (
+	Strict = Converter{}
+	Lossy  = Converter{Lossy: true}
+)
+
+// DefaultTimeLayouts are the layouts tried by the lossy mode, most precise first
+var DefaultTimeLayouts = []string{
+	time.RFC3339Nano,
+	time.DateTime,
+	"2006-01-02T15:04:05",
+	time.DateOnly,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.ANSIC,
+}
+
+var (
+	durationType   = reflect.TypeOf(time.Duration(0))
+	timeType       = reflect.TypeOf(time.Time{})
+	jsonNumberType = reflect.TypeOf(json.Number(""))
+)
+
+// value looks through pointers and interfaces to the underlying value.
+// A nil value is not valid, the caller decides what it converts to
+func value(v any) reflect.Value {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+	return rv
+}
+
+// fail builds the *Error for converting "v" to "target"
+func fail(v any, target string, err error) *Error {
+	return &Error{Value: v, Target: target, Err: err}
+}
@@ -0,0 +1,284 @@
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+type stringer struct{}
+
+func (stringer) String() string { return "stringer" }
+
+func TestInt64(t *testing.T) {
+	n := 7
+	tests := []struct {
+		c    Converter
+		v    any
+		want int64
+		err  error
+	}{
+		{Strict, 42, 42, nil},
+		{Strict, int8(-3), -3, nil},
+		{Strict, uint64(math.MaxInt64), math.MaxInt64, nil},
+		{Strict, uint64(math.MaxInt64 + 1), 0, ErrRange},
+		{Strict, 3.0, 3, nil},
+		{Strict, 3.5, 0, ErrLoss},
+		{Strict, -0x1p63, math.MinInt64, nil},
+		{Strict, 0x1p63, 0, ErrRange},
+		{Strict, math.NaN(), 0, ErrRange},
+		{Strict, json.Number("12"), 12, nil},
+		{Strict, json.Number("1e3"), 1000, nil},
+		{Strict, "12", 0, ErrType},
+		{Strict, true, 0, ErrType},
+		{Strict, nil, 0, ErrType},
+		{Strict, &n, 7, nil},
+		{Strict, []int{1}, 0, ErrType},
+		{Lossy, 3.9, 3, nil},
+		{Lossy, -3.9, -3, nil},
+		{Lossy, " 0x1f ", 31, nil},
+		{Lossy, "2.5", 2, nil},
+		{Lossy, "99999999999999999999", 0, ErrRange},
+		{Lossy, "abc", 0, ErrSyntax},
+		{Lossy, true, 1, nil},
+		{Lossy, false, 0, nil},
+		{Lossy, nil, 0, nil},
+		{Lossy, (*int)(nil), 0, nil},
+	}
+	for _, tt := range tests {
+		got, err := tt.c.Int64(tt.v)
+		if got != tt.want || !errors.Is(err, tt.err) || (tt.err == nil) != (err == nil) {
+			t.Errorf("Lossy=%v Int64(%#v) = %d, %v, want %d, %v", tt.c.Lossy, tt.v, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestOtherNumbers(t *testing.T) {
+	if n, err := Strict.Int(json.Number("5")); n != 5 || err != nil {
+		t.Errorf("Int = %d, %v", n, err)
+	}
+	var e *Error
+	if _, err := Strict.Int("5"); !errors.As(err, &e) || e.Target != "int" {
+		t.Errorf("the error of Int should name its target: %v", err)
+	}
+
+	uints := []struct {
+		c    Converter
+		v    any
+		want uint64
+		err  error
+	}{
+		{Strict, uint64(math.MaxUint64), math.MaxUint64, nil},
+		{Strict, 0x1p63, 1 << 63, nil},
+		{Strict, json.Number("18446744073709551615"), math.MaxUint64, nil},
+		{Strict, -1, 0, ErrRange},
+		{Strict, 0x1p64, 0, ErrRange},
+		{Lossy, "18446744073709551615", math.MaxUint64, nil},
+		{Lossy, "-1", 0, ErrRange},
+	}
+	for _, tt := range uints {
+		got, err := tt.c.Uint64(tt.v)
+		if got != tt.want || !errors.Is(err, tt.err) || (tt.err == nil) != (err == nil) {
+			t.Errorf("Lossy=%v Uint64(%#v) = %d, %v, want %d, %v", tt.c.Lossy, tt.v, got, err, tt.want, tt.err)
+		}
+	}
+
+	floats := []struct {
+		c    Converter
+		v    any
+		want float64
+		err  error
+	}{
+		{Strict, float32(0.5), 0.5, nil},
+		{Strict, 1 << 53, 1 << 53, nil},
+		{Strict, 1<<53 + 1, 0, ErrLoss},
+		{Strict, int64(math.MaxInt64), 0, ErrLoss},
+		{Strict, uint64(math.MaxUint64), 0, ErrLoss},
+		{Strict, json.Number("2.5"), 2.5, nil},
+		{Strict, "2.5", 0, ErrType},
+		{Lossy, 1<<53 + 1, 1 << 53, nil},
+		{Lossy, "1e400", 0, ErrRange},
+		{Lossy, "x", 0, ErrSyntax},
+		{Lossy, true, 1, nil},
+		{Lossy, nil, 0, nil},
+	}
+	for _, tt := range floats {
+		got, err := tt.c.Float64(tt.v)
+		if got != tt.want || !errors.Is(err, tt.err) || (tt.err == nil) != (err == nil) {
+			t.Errorf("Lossy=%v Float64(%#v) = %v, %v, want %v, %v", tt.c.Lossy, tt.v, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestBoolAndString(t *testing.T) {
+	bools := []struct {
+		c    Converter
+		v    any
+		want bool
+		err  error
+	}{
+		{Strict, true, true, nil},
+		{Strict, 1, false, ErrType},
+		{Lossy, 2, true, nil},
+		{Lossy, uint(0), false, nil},
+		{Lossy, 0.0, false, nil},
+		{Lossy, math.NaN(), false, ErrRange},
+		{Lossy, " YES ", true, nil},
+		{Lossy, "off", false, nil},
+		{Lossy, "maybe", false, ErrSyntax},
+		{Lossy, nil, false, nil},
+		{Lossy, []int{}, false, ErrType},
+	}
+	for _, tt := range bools {
+		got, err := tt.c.Bool(tt.v)
+		if got != tt.want || !errors.Is(err, tt.err) || (tt.err == nil) != (err == nil) {
+			t.Errorf("Lossy=%v Bool(%#v) = %v, %v, want %v, %v", tt.c.Lossy, tt.v, got, err, tt.want, tt.err)
+		}
+	}
+
+	at := time.Date(2026, time.October, 19, 10, 30, 0, 5, time.UTC)
+	strs := []struct {
+		c    Converter
+		v    any
+		want string
+		err  error
+	}{
+		{Strict, "s", "s", nil},
+		{Strict, []byte("b"), "b", nil},
+		{Strict, json.Number("1.5"), "1.5", nil},
+		{Strict, 1, "", ErrType},
+		{Lossy, -12, "-12", nil},
+		{Lossy, uint8(200), "200", nil},
+		{Lossy, float32(0.1), "0.1", nil},
+		{Lossy, 0.1, "0.1", nil},
+		{Lossy, false, "false", nil},
+		{Lossy, at, "2026-10-19T10:30:00.000000005Z", nil},
+		{Lossy, 90 * time.Second, "1m30s", nil},
+		{Lossy, stringer{}, "stringer", nil},
+		{Lossy, errors.New("e"), "e", nil},
+		{Lossy, nil, "", nil},
+		{Lossy, struct{}{}, "", ErrType},
+	}
+	for _, tt := range strs {
+		got, err := tt.c.String(tt.v)
+		if got != tt.want || !errors.Is(err, tt.err) || (tt.err == nil) != (err == nil) {
+			t.Errorf("Lossy=%v String(%#v) = %q, %v, want %q, %v", tt.c.Lossy, tt.v, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestTimeAndDuration(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*3600)
+	at := time.Date(2026, time.October, 19, 10, 30, 0, 0, time.UTC)
+	times := []struct {
+		c    Converter
+		v    any
+		want time.Time
+		err  error
+	}{
+		{Strict, at, at, nil},
+		{Strict, "2026-10-19T10:30:00Z", at, nil},
+		{Strict, "2026-10-19 10:30:00", time.Time{}, ErrSyntax},
+		{Strict, 1.0, time.Time{}, ErrType},
+		{Strict, nil, time.Time{}, ErrType},
+		{Lossy, "2026-10-19 10:30:00", at, nil},
+		{Lossy, "2026-10-19", at.Truncate(24 * time.Hour), nil},
+		{Converter{Lossy: true, Location: tokyo}, "2026-10-19 19:30:00", at, nil},
+		{Converter{Lossy: true, TimeLayouts: []string{"02/01/2006"}}, "19/10/2026", at.Truncate(24 * time.Hour), nil},
+		{Converter{Lossy: true, TimeLayouts: []string{"02/01/2006"}}, "2026-10-19", time.Time{}, ErrSyntax},
+		{Lossy, at.Unix(), at, nil},
+		{Lossy, 1.5, time.Unix(1, 5e8), nil},
+		{Lossy, 1e300, time.Time{}, ErrRange},
+		{Lossy, true, time.Unix(1, 0), nil},
+	}
+	for _, tt := range times {
+		got, err := tt.c.Time(tt.v)
+		if !got.Equal(tt.want) || !errors.Is(err, tt.err) || (tt.err == nil) != (err == nil) {
+			t.Errorf("Time(%#v) = %v, %v, want %v, %v", tt.v, got, err, tt.want, tt.err)
+		}
+	}
+
+	durations := []struct {
+		c    Converter
+		v    any
+		want time.Duration
+		err  error
+	}{
+		{Strict, 2 * time.Minute, 2 * time.Minute, nil},
+		{Strict, "1h30m", 90 * time.Minute, nil},
+		{Strict, "90", 0, ErrSyntax},
+		{Strict, 90, 0, ErrType},
+		{Strict, "soon", 0, ErrSyntax},
+		{Lossy, "90", 90 * time.Second, nil},
+		{Lossy, 1.5, 1500 * time.Millisecond, nil},
+		{Lossy, json.Number("2"), 2 * time.Second, nil},
+		{Lossy, 1e12, 0, ErrRange},
+		{Lossy, nil, 0, nil},
+		{Lossy, "soon", 0, ErrSyntax},
+	}
+	for _, tt := range durations {
+		got, err := tt.c.Duration(tt.v)
+		if got != tt.want || !errors.Is(err, tt.err) || (tt.err == nil) != (err == nil) {
+			t.Errorf("Lossy=%v Duration(%#v) = %v, %v, want %v, %v", tt.c.Lossy, tt.v, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestCollections(t *testing.T) {
+	var decoded any
+	json.Unmarshal([]byte(`{"servers": [{"port": 80}, {"port": 8080.5}], "timeouts": {"read": "5s", "write": 2}}`), &decoded)
+	config := decoded.(map[string]any)
+
+	ports, err := Slice([]any{80.0, json.Number("443"), 8080}, Strict.Int)
+	if err != nil || len(ports) != 3 || ports[1] != 443 {
+		t.Errorf("Slice = %v, %v", ports, err)
+	}
+	if got, err := Slice([2]string{"a", "b"}, Strict.String); err != nil || strings.Join(got, "") != "ab" {
+		t.Errorf("Slice of an array = %v, %v", got, err)
+	}
+	if got, err := Slice([]int(nil), Strict.Int); got != nil || err != nil {
+		t.Errorf("Slice(nil) = %v, %v", got, err)
+	}
+	if _, err := Slice("abc", Strict.Int); !errors.Is(err, ErrType) {
+		t.Errorf("Slice of a string: %v", err)
+	}
+
+	// The path of a nested error locates the value
+	_, err = Slice(config["servers"], func(v any) (int, error) {
+		m, err := Map(v, Strict.Int)
+		return m["port"], err
+	})
+	var e *Error
+	if !errors.As(err, &e) || e.Path != "[1].port" || !errors.Is(err, ErrLoss) {
+		t.Errorf("nested error: %v", err)
+	}
+	if want := "convert: [1].port: cannot convert float64(8080.5) to int: value would lose information"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	timeouts, err := Map(config["timeouts"], Lossy.Duration)
+	if err != nil || timeouts["read"] != 5*time.Second || timeouts["write"] != 2*time.Second {
+		t.Errorf("Map = %v, %v", timeouts, err)
+	}
+	if _, err := Map(map[int]int{1: 1}, Strict.Int); !errors.Is(err, ErrType) {
+		t.Errorf("Map with int keys: %v", err)
+	}
+	if got, err := Map(map[string]any(nil), Strict.Int); got != nil || err != nil {
+		t.Errorf("Map(nil) = %v, %v", got, err)
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	_, err := Strict.Int64(strings.Repeat("x", 50))
+	want := `convert: cannot convert string "` + strings.Repeat("x", 40) + `..." to int64: unsupported type`
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	_, err = Strict.Int64(nil)
+	if !strings.Contains(err.Error(), "cannot convert nil to int64") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
@@ -0,0 +1,203 @@
+package convert
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Int converts "v" to an int, see Int64
+func (c Converter) Int(v any) (int, error) {
+	n, err := c.Int64(v)
+	if err != nil {
+		if e, ok := err.(*Error); ok {
+			e.Target = "int"
+		}
+		return 0, err
+	}
+	if n < math.MinInt || n > math.MaxInt {
+		return 0, fail(v, "int", ErrRange)
+	}
+	return int(n), nil
+}
+
+// Int64 converts "v" to an int64.
+// Strict accepts integers of any type, json.Number, and floats with no fractional part.
+// Lossy also truncates fractions towards zero, parses strings,
+// and turns booleans into 0 and 1 and nil into 0.
+// Values beyond the range of int64 always fail with ErrRange
+func (c Converter) Int64(v any) (int64, error) {
+	const target = "int64"
+
+	rv := value(v)
+	if !rv.IsValid() {
+		if c.Lossy {
+			return 0, nil
+		}
+		return 0, fail(v, target, ErrType)
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return 0, fail(v, target, ErrRange)
+		}
+		return int64(rv.Uint()), nil
+
+	case reflect.Float32, reflect.Float64:
+		n, err := c.floatToInt(rv.Float())
+		if err != nil {
+			return 0, fail(v, target, err)
+		}
+		return n, nil
+
+	case reflect.String:
+		if rv.Type() != jsonNumberType && !c.Lossy {
+			return 0, fail(v, target, ErrType)
+		}
+		s := strings.TrimSpace(rv.String())
+		if n, err := strconv.ParseInt(s, 0, 64); err == nil {
+			return n, nil
+		} else if errors.Is(err, strconv.ErrRange) {
+			return 0, fail(v, target, ErrRange)
+		}
+
+		// Numbers such as "1e3" or "2.5" are not integers in their text form
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fail(v, target, ErrSyntax)
+		}
+		n, err := c.floatToInt(f)
+		if err != nil {
+			return 0, fail(v, target, err)
+		}
+		return n, nil
+
+	case reflect.Bool:
+		if c.Lossy {
+			if rv.Bool() {
+				return 1, nil
+			}
+			return 0, nil
+		}
+	}
+	return 0, fail(v, target, ErrType)
+}
+
+func (c Converter) floatToInt(f float64) (int64, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, ErrRange
+	}
+	if t := math.Trunc(f); t != f {
+		if !c.Lossy {
+			return 0, ErrLoss
+		}
+		f = t
+	}
+
+	// 2⁶³ is the first float64 above the int64 range, -2⁶³ is the last one in it
+	if f < -0x1p63 || f >= 0x1p63 {
+		return 0, ErrRange
+	}
+	return int64(f), nil
+}
+
+// Uint64 converts "v" to a uint64 like Int64 does, negative values fail with ErrRange
+func (c Converter) Uint64(v any) (uint64, error) {
+	const target = "uint64"
+
+	rv := value(v)
+	if rv.IsValid() {
+		switch rv.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return rv.Uint(), nil
+		case reflect.Float32, reflect.Float64:
+			if f := rv.Float(); f >= 0x1p63 && f < 0x1p64 && f == math.Trunc(f) {
+				return uint64(f), nil
+			}
+		case reflect.String:
+			s := strings.TrimSpace(rv.String())
+			if rv.Type() == jsonNumberType || c.Lossy {
+				if n, err := strconv.ParseUint(s, 0, 64); err == nil {
+					return n, nil
+				}
+			}
+		}
+	}
+
+	n, err := c.Int64(v)
+	if err != nil {
+		if e, ok := err.(*Error); ok {
+			e.Target = target
+		}
+		return 0, err
+	}
+	if n < 0 {
+		return 0, fail(v, target, ErrRange)
+	}
+	return uint64(n), nil
+}
+
+// Float64 converts "v" to a float64.
+// Strict accepts floats, json.Number, and integers small enough to be represented exactly.
+// Lossy also rounds large integers, parses strings,
+// and turns booleans into 0 and 1 and nil into 0
+func (c Converter) Float64(v any) (float64, error) {
+	const target = "float64"
+
+	rv := value(v)
+	if !rv.IsValid() {
+		if c.Lossy {
+			return 0, nil
+		}
+		return 0, fail(v, target, ErrType)
+	}
+
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := rv.Int()
+		f := float64(n)
+		if !c.Lossy && (f >= 0x1p63 || int64(f) != n) {
+			return 0, fail(v, target, ErrLoss)
+		}
+		return f, nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n := rv.Uint()
+		f := float64(n)
+		if !c.Lossy && (f >= 0x1p64 || uint64(f) != n) {
+			return 0, fail(v, target, ErrLoss)
+		}
+		return f, nil
+
+	case reflect.String:
+		if rv.Type() != jsonNumberType && !c.Lossy {
+			return 0, fail(v, target, ErrType)
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(rv.String()), 64)
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, fail(v, target, ErrRange)
+		}
+		if err != nil {
+			return 0, fail(v, target, ErrSyntax)
+		}
+		return f, nil
+
+	case reflect.Bool:
+		if c.Lossy {
+			if rv.Bool() {
+				return 1, nil
+			}
+			return 0, nil
+		}
+	}
+	return 0, fail(v, target, ErrType)
+}
@@ -0,0 +1,205 @@
+package convert
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bool converts "v" to a bool.
+// Strict only accepts booleans. Lossy also accepts numbers, true when not zero,
+// strings such as "true", "1", "yes" or "on" and their opposites, and nil as false
+func (c Converter) Bool(v any) (bool, error) {
+	const target = "bool"
+
+	rv := value(v)
+	if !rv.IsValid() {
+		if c.Lossy {
+			return false, nil
+		}
+		return false, fail(v, target, ErrType)
+	}
+	if rv.Kind() == reflect.Bool {
+		return rv.Bool(), nil
+	}
+	if !c.Lossy {
+		return false, fail(v, target, ErrType)
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() != 0, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint() != 0, nil
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(rv.Float()) {
+			return false, fail(v, target, ErrRange)
+		}
+		return rv.Float() != 0, nil
+	case reflect.String:
+		switch strings.ToLower(strings.TrimSpace(rv.String())) {
+		case "1", "t", "true", "y", "yes", "on":
+			return true, nil
+		case "0", "f", "false", "n", "no", "off":
+			return false, nil
+		}
+		return false, fail(v, target, ErrSyntax)
+	}
+	return false, fail(v, target, ErrType)
+}
+
+// String converts "v" to a string.
+// Strict accepts strings, json.Number and byte slices.
+// Lossy also formats numbers, booleans, times in RFC 3339, durations,
+// and values implementing "fmt.Stringer" or "error", and turns nil into ""
+func (c Converter) String(v any) (string, error) {
+	const target = "string"
+
+	rv := value(v)
+	if !rv.IsValid() {
+		if c.Lossy {
+			return "", nil
+		}
+		return "", fail(v, target, ErrType)
+	}
+
+	switch {
+	case rv.Kind() == reflect.String:
+		return rv.String(), nil
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+		return string(rv.Bytes()), nil
+	case !c.Lossy:
+		return "", fail(v, target, ErrType)
+	case rv.Type() == timeType:
+		return rv.Interface().(time.Time).Format(time.RFC3339Nano), nil
+	}
+
+	// The methods of the value as given first, "error" is often implemented on a pointer
+	if s, ok := describeSelf(v); ok {
+		return s, nil
+	}
+	if rv.CanInterface() {
+		if s, ok := describeSelf(rv.Interface()); ok {
+			return s, nil
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	}
+	return "", fail(v, target, ErrType)
+}
+
+// describeSelf returns the text of values implementing "fmt.Stringer" or "error"
+func describeSelf(v any) (string, bool) {
+	switch s := v.(type) {
+	case fmt.Stringer:
+		return s.String(), true
+	case error:
+		return s.Error(), true
+	}
+	return "", false
+}
+
+// Time converts "v" to a time.Time.
+// Strict accepts times and RFC 3339 strings.
+// Lossy also tries the TimeLayouts, and takes numbers as seconds since the Unix epoch
+func (c Converter) Time(v any) (time.Time, error) {
+	const target = "time.Time"
+
+	rv := value(v)
+	if !rv.IsValid() {
+		return time.Time{}, fail(v, target, ErrType)
+	}
+	if rv.Type() == timeType {
+		return rv.Interface().(time.Time), nil
+	}
+
+	loc := c.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	if rv.Kind() == reflect.String && rv.Type() != jsonNumberType {
+		s := strings.TrimSpace(rv.String())
+		layouts := []string{time.RFC3339Nano}
+		if c.Lossy {
+			layouts = c.TimeLayouts
+			if len(layouts) == 0 {
+				layouts = DefaultTimeLayouts
+			}
+		}
+		for _, layout := range layouts {
+			if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fail(v, target, ErrSyntax)
+	}
+
+	if !c.Lossy {
+		return time.Time{}, fail(v, target, ErrType)
+	}
+	secs, err := c.Float64(v)
+	if err != nil {
+		return time.Time{}, fail(v, target, ErrType)
+	}
+	if math.IsNaN(secs) || math.Abs(secs) >= 1<<62/1e9 {
+		return time.Time{}, fail(v, target, ErrRange)
+	}
+	whole, frac := math.Modf(secs)
+	return time.Unix(int64(whole), int64(frac*1e9)).In(loc), nil
+}
+
+// Duration converts "v" to a time.Duration.
+// Strict accepts durations and strings such as "1h30m".
+// Lossy also takes numbers, and strings made of a number alone, as seconds.
+// Strict rejects numbers because JSON and configuration files rarely mean nanoseconds
+func (c Converter) Duration(v any) (time.Duration, error) {
+	const target = "time.Duration"
+
+	rv := value(v)
+	if !rv.IsValid() {
+		if c.Lossy {
+			return 0, nil
+		}
+		return 0, fail(v, target, ErrType)
+	}
+	if rv.Type() == durationType {
+		return time.Duration(rv.Int()), nil
+	}
+
+	if rv.Kind() == reflect.String && rv.Type() != jsonNumberType {
+		s := strings.TrimSpace(rv.String())
+		if d, err := time.ParseDuration(s); err == nil {
+			return d, nil
+		}
+		if _, err := strconv.ParseFloat(s, 64); err != nil || !c.Lossy {
+			return 0, fail(v, target, ErrSyntax)
+		}
+	} else if !c.Lossy {
+		return 0, fail(v, target, ErrType)
+	}
+
+	secs, err := c.Float64(v)
+	if err != nil {
+		return 0, fail(v, target, ErrType)
+	}
+	d := secs * float64(time.Second)
+	if math.IsNaN(d) || d < math.MinInt64 || d >= math.MaxInt64 {
+		return 0, fail(v, target, ErrRange)
+	}
+	return time.Duration(math.Round(d)), nil
+}
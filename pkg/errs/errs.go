@@ -0,0 +1,252 @@
+// Package errs provides errors that carry a machine-readable code,
+// structured key/value fields, the stack trace of where they were created,
+// and the error they wrap.
+//
+// Unlike recovering a custom error type with a type assertion,
+// everything here keeps working once errors are wrapped,
+// because it goes through "errors.Is" and "errors.As":
+//
+//	err := errs.Wrap(io.ErrUnexpectedEOF, errs.InvalidArgument, "cannot parse config", "line", 42)
+//	errors.Is(err, errs.InvalidArgument) // true, codes match
+//	errors.Is(err, io.ErrUnexpectedEOF)  // true, the cause is preserved
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Code classifies an error independently of its message.
+// A Code is itself an error, so that "errors.Is(err, errs.NotFound)" matches
+// any *Error in the chain with that code
+type Code string
+
+// The codes known to this package, each of them maps to an HTTP status
+const (
+	Unknown           Code = "unknown"
+	InvalidArgument   Code = "invalid_argument"
+	NotFound          Code = "not_found"
+	AlreadyExists     Code = "already_exists"
+	Conflict          Code = "conflict"
+	PermissionDenied  Code = "permission_denied"
+	Unauthenticated   Code = "unauthenticated"
+	ResourceExhausted Code = "resource_exhausted"
+	DeadlineExceeded  Code = "deadline_exceeded"
+	Unavailable       Code = "unavailable"
+	Unimplemented     Code = "unimplemented"
+	Internal          Code = "internal"
+)
+
+var httpStatus = map[Code]int{
+	Unknown:           http.StatusInternalServerError,
+	InvalidArgument:   http.StatusBadRequest,
+	NotFound:          http.StatusNotFound,
+	AlreadyExists:     http.StatusConflict,
+	Conflict:          http.StatusConflict,
+	PermissionDenied:  http.StatusForbidden,
+	Unauthenticated:   http.StatusUnauthorized,
+	ResourceExhausted: http.StatusTooManyRequests,
+	DeadlineExceeded:  http.StatusGatewayTimeout,
+	Unavailable:       http.StatusServiceUnavailable,
+	Unimplemented:     http.StatusNotImplemented,
+	Internal:          http.StatusInternalServerError,
+}
+
+func (c Code) Error() string {
+	return string(c)
+}
+
+// HTTPStatus returns the HTTP status code that best describes "c",
+// 500 for codes this package does not know
+func (c Code) HTTPStatus() int {
+	if status, ok := httpStatus[c]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// Field is a single key/value pair attached to an error
+type Field struct {
+	Key   string
+	Value any
+}
+
+// Error is an error with a code, a message, fields, a stack trace and an optional cause
+type Error struct {
+	Code    Code
+	Message string
+	Fields  []Field
+	Cause   error
+
+	stack stack
+}
+
+// New returns an error with the given code and message.
+// "fields" are alternating keys and values, e.g. New(NotFound, "no such user", "id", 42)
+func New(code Code, message string, fields ...any) *Error {
+	return &Error{Code: code, Message: message, Fields: toFields(fields), stack: callers()}
+}
+
+// Errorf returns an error with the given code and a formatted message.
+// Unlike "fmt.Errorf", the "%w" verb is not supported, use Wrap to keep a cause
+func Errorf(code Code, format string, args ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...), stack: callers()}
+}
+
+// Wrap returns an *Error with the given code and message that wraps "cause",
+// or nil when "cause" is nil so that it can wrap a return value unconditionally.
+// It returns an "error" rather than an *Error so that the nil is a true nil interface
+func Wrap(cause error, code Code, message string, fields ...any) error {
+	if cause == nil {
+		return nil
+	}
+	return &Error{Code: code, Message: message, Fields: toFields(fields), Cause: cause, stack: callers()}
+}
+
+// With returns a copy of "e" with the key/value pairs appended to its fields
+func (e *Error) With(fields ...any) *Error {
+	c := *e
+	c.Fields = append(slices.Clip(e.Fields), toFields(fields)...)
+	return &c
+}
+
+// Error returns the message followed by the message of the cause, like "fmt.Errorf" with "%w"
+func (e *Error) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = string(e.Code)
+	}
+	if e.Cause == nil {
+		return msg
+	}
+	return msg + ": " + e.Cause.Error()
+}
+
+// Unwrap returns the cause, for "errors.Is" and "errors.As"
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether "target" is the code of "e", or an *Error with the same code and message
+func (e *Error) Is(target error) bool {
+	switch t := target.(type) {
+	case Code:
+		return e.Code == t
+	case *Error:
+		return e.Code == t.Code && e.Message == t.Message
+	}
+	return false
+}
+
+// Field returns the value of the first field named "key" anywhere in the chain of "e"
+func (e *Error) Field(key string) (any, bool) {
+	for err := error(e); err != nil; err = errors.Unwrap(err) {
+		x, ok := err.(*Error)
+		if !ok {
+			continue
+		}
+		for _, f := range x.Fields {
+			if f.Key == key {
+				return f.Value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// StackTrace returns the frames of the call stack where "e" was created
+func (e *Error) StackTrace() []Frame {
+	return e.stack.frames()
+}
+
+// Format implements "fmt.Formatter":
+// "%v" and "%s" print the message like Error, "%+v" adds the code,
+// the fields and the stack trace of every *Error in the chain
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		e.formatVerbose(s)
+	case verb == 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	default:
+		io.WriteString(s, e.Error())
+	}
+}
+
+func (e *Error) formatVerbose(w io.Writer) {
+	var b strings.Builder
+	for err := error(e); err != nil; err = errors.Unwrap(err) {
+		x, ok := err.(*Error)
+		if !ok {
+			fmt.Fprintf(&b, "caused by: %v\n", err)
+			break
+		}
+		if x != e {
+			b.WriteString("caused by: ")
+		}
+		fmt.Fprintf(&b, "[%s] %s", x.Code, x.Message)
+		for _, f := range x.Fields {
+			fmt.Fprintf(&b, " %s=%v", f.Key, f.Value)
+		}
+		b.WriteByte('\n')
+		for _, f := range x.StackTrace() {
+			fmt.Fprintf(&b, "\t%s\n\t\t%s:%d\n", f.Function, f.File, f.Line)
+		}
+	}
+	io.WriteString(w, strings.TrimSuffix(b.String(), "\n"))
+}
+
+// CodeOf returns the code of the first *Error in the chain of "err",
+// Unknown when there is none, or an empty Code when "err" is nil
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	var c Code
+	if errors.As(err, &c) {
+		return c
+	}
+	return Unknown
+}
+
+// FieldsOf collects the fields of every *Error in the chain of "err", outermost first
+func FieldsOf(err error) []Field {
+	var fields []Field
+	for ; err != nil; err = errors.Unwrap(err) {
+		if e, ok := err.(*Error); ok {
+			fields = append(fields, e.Fields...)
+		}
+	}
+	return fields
+}
+
+// toFields pairs up alternating keys and values,
+// a key that is not a string is formatted with "%v" and a missing value is nil
+func toFields(kv []any) []Field {
+	if len(kv) == 0 {
+		return nil
+	}
+
+	fields := make([]Field, 0, (len(kv)+1)/2)
+	for i := 0; i < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			key = fmt.Sprint(kv[i])
+		}
+
+		var value any
+		if i+1 < len(kv) {
+			value = kv[i+1]
+		}
+		fields = append(fields, Field{Key: key, Value: value})
+	}
+	return fields
+}
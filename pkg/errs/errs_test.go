@@ -0,0 +1,187 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestIsAndAs(t *testing.T) {
+	err := fmt.Errorf("handler: %w", Wrap(io.ErrUnexpectedEOF, InvalidArgument, "cannot parse config", "line", 42))
+
+	if !errors.Is(err, InvalidArgument) || errors.Is(err, NotFound) {
+		t.Error("errors.Is does not match the code")
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Error("errors.Is does not find the cause")
+	}
+
+	var e *Error
+	if !errors.As(err, &e) || e.Message != "cannot parse config" {
+		t.Fatalf("errors.As: %v", e)
+	}
+	if v, ok := e.Field("line"); !ok || v != 42 {
+		t.Errorf("Field(line) = %v, %v", v, ok)
+	}
+	if got := CodeOf(err); got != InvalidArgument {
+		t.Errorf("CodeOf = %q", got)
+	}
+	if got := err.Error(); got != "handler: cannot parse config: unexpected EOF" {
+		t.Errorf("Error() = %q", got)
+	}
+	if len(e.StackTrace()) == 0 || !strings.Contains(e.StackTrace()[0].Function, "TestIsAndAs") {
+		t.Errorf("stack trace does not start in the test: %v", e.StackTrace())
+	}
+	if Wrap(nil, Internal, "x") != nil {
+		t.Error("Wrap(nil) is not nil")
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		err  error
+		want Code
+	}{
+		{nil, ""},
+		{errors.New("plain"), Unknown},
+		{NotFound, NotFound},
+		{fmt.Errorf("wrapped: %w", Conflict), Conflict},
+		{New(Unavailable, "down"), Unavailable},
+	}
+	for _, tt := range tests {
+		if got := CodeOf(tt.err); got != tt.want {
+			t.Errorf("CodeOf(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	inner := New(NotFound, "no such user", "id", 42)
+	var list List
+	list.Add(Wrap(inner, Internal, "lookup failed", "op", "get"))
+	list.Add(New(InvalidArgument, "bad email", "field", "email"))
+	list.Add(errors.New("plain"))
+
+	data, err := MarshalJSON(fmt.Errorf("request: %w", list.Err()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := FromJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The wrapping by "fmt.Errorf" is reduced to a message, the rest of the chain survives
+	var multi *MultiError
+	if errors.As(decoded, &multi) {
+		t.Fatalf("the outer error should decode as a plain error, got %v", decoded)
+	}
+
+	data, _ = MarshalJSON(list.Err())
+	decoded, err = FromJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !errors.As(decoded, &multi) || len(multi.Errors) != 3 {
+		t.Fatalf("errors.As(*MultiError): %#v", decoded)
+	}
+	for _, code := range []Code{NotFound, Internal, InvalidArgument} {
+		if !errors.Is(decoded, code) {
+			t.Errorf("errors.Is(%q) is false after decoding", code)
+		}
+	}
+	if !errors.Is(decoded, New(NotFound, "no such user")) {
+		t.Error("errors.Is does not match an *Error with the same code and message")
+	}
+
+	var e *Error
+	if !errors.As(multi.Errors[0], &e) || e.Code != Internal {
+		t.Fatalf("errors.As(*Error) = %v", e)
+	}
+	if v, ok := e.Field("id"); !ok || v != float64(42) {
+		t.Errorf("Field(id) = %v (%T)", v, v)
+	}
+	if got, want := decoded.Error(), list.Err().Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestErrorUnmarshalJSON(t *testing.T) {
+	var e Error
+	if err := e.UnmarshalJSON([]byte(`{"code":"conflict","message":"taken","fields":{"b":1,"a":2}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if e.Code != Conflict || e.Message != "taken" || len(e.Fields) != 2 || e.Fields[0].Key != "a" {
+		t.Errorf("got %+v", e)
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	data, err := MarshalJSON(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded, err := FromJSON(data); decoded != nil || err != nil {
+		t.Errorf("FromJSON(%s) = %v, %v", data, decoded, err)
+	}
+
+	for _, body := range []string{`{"error":"x"}`, `{}`, `[1]`, `oops`, `"text"`} {
+		if decoded, err := FromJSON([]byte(body)); err == nil {
+			t.Errorf("FromJSON(%s) = %v, expected an error", body, decoded)
+		}
+	}
+
+	decoded, err := FromJSON([]byte(`{"message":"plain"}`))
+	if err != nil || decoded.Error() != "plain" {
+		t.Errorf("FromJSON(message) = %v, %v", decoded, err)
+	}
+}
+
+func TestHTTP(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteHTTP(rec, Wrap(io.EOF, NotFound, "no such user", "id", 7))
+	resp := rec.Result()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status %d", resp.StatusCode)
+	}
+
+	err := ReadHTTP(resp)
+	var e *Error
+	if !errors.As(err, &e) || e.Code != NotFound || e.Error() != "no such user: EOF" {
+		t.Errorf("ReadHTTP = %v", err)
+	}
+
+	// A body this package did not write falls back to the status
+	for _, body := range []string{`{"error":"x"}`, `<html>Bad Gateway</html>`, ``} {
+		resp := &http.Response{
+			StatusCode: http.StatusBadGateway,
+			Status:     "502 Bad Gateway",
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+		err := ReadHTTP(resp)
+		if CodeOf(err) != Internal || err.Error() != "unexpected status: 502 Bad Gateway" {
+			t.Errorf("ReadHTTP(%q) = %v (%s)", body, err, CodeOf(err))
+		}
+	}
+
+	if err := ReadHTTP(&http.Response{StatusCode: http.StatusOK, Body: http.NoBody}); err != nil {
+		t.Errorf("ReadHTTP(200) = %v", err)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	err := Wrap(errors.New("disk full"), Unavailable, "cannot save", "path", "/tmp/x")
+	verbose := fmt.Sprintf("%+v", err)
+	for _, want := range []string{"[unavailable] cannot save path=/tmp/x", "caused by: disk full", "TestFormat"} {
+		if !strings.Contains(verbose, want) {
+			t.Errorf("%%+v does not contain %q:\n%s", want, verbose)
+		}
+	}
+	if got := fmt.Sprintf("%v", err); got != "cannot save: disk full" {
+		t.Errorf("%%v = %q", got)
+	}
+}
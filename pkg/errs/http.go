@@ -0,0 +1,67 @@
+package errs
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// WriteHTTP writes "err" as a JSON response,
+// with the HTTP status that corresponds to its code
+func WriteHTTP(w http.ResponseWriter, err error) {
+	body, merr := MarshalJSON(err)
+	if merr != nil {
+		body = []byte(`{"code":"internal","message":"cannot encode error"}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(CodeOf(err).HTTPStatus())
+	w.Write(append(body, '\n'))
+}
+
+// ReadHTTP returns nil for a successful response,
+// otherwise the error written by WriteHTTP, or an *Error built from the status
+// when the body is not an encoded error, e.g. the error page of a proxy.
+// The body is read but not closed
+func ReadHTTP(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err == nil {
+		if decoded, derr := FromJSON(body); derr == nil && decoded != nil {
+			return decoded
+		}
+	}
+
+	return New(codeForStatus(resp.StatusCode), fmt.Sprintf("unexpected status: %s", resp.Status))
+}
+
+// codeForStatus is the reverse of Code.HTTPStatus, picking the most general code
+func codeForStatus(status int) Code {
+	switch status {
+	case http.StatusBadRequest:
+		return InvalidArgument
+	case http.StatusNotFound:
+		return NotFound
+	case http.StatusConflict:
+		return Conflict
+	case http.StatusForbidden:
+		return PermissionDenied
+	case http.StatusUnauthorized:
+		return Unauthenticated
+	case http.StatusTooManyRequests:
+		return ResourceExhausted
+	case http.StatusGatewayTimeout:
+		return DeadlineExceeded
+	case http.StatusServiceUnavailable:
+		return Unavailable
+	case http.StatusNotImplemented:
+		return Unimplemented
+	}
+	if status >= http.StatusInternalServerError {
+		return Internal
+	}
+	return Unknown
+}
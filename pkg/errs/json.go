@@ -0,0 +1,148 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// jsonError is the wire format shared by every kind of error:
+// an *Error has a code, a *MultiError has a list of errors,
+// any other error is reduced to its message
+type jsonError struct {
+	Code    Code           `json:"code,omitempty"`
+	Message string         `json:"message,omitempty"`
+	Fields  map[string]any `json:"fields,omitempty"`
+	Cause   *jsonError     `json:"cause,omitempty"`
+	Errors  []*jsonError   `json:"errors,omitempty"`
+}
+
+// MarshalJSON encodes any error, keeping the codes, fields and causes
+// of the *Error and *MultiError values in its chain.
+// Stack traces are deliberately left out, they are for the process that created them
+func MarshalJSON(err error) ([]byte, error) {
+	return json.Marshal(toJSON(err))
+}
+
+// errNotPayload reports JSON that was not written by MarshalJSON, e.g. {"error":"x"}
+var errNotPayload = errors.New("errs: not an errs payload")
+
+// FromJSON decodes an error encoded by MarshalJSON, "null" being the nil error.
+// The first result is the decoded error, the second one reports malformed input,
+// or an object without any of the "code", "message" and "errors" members.
+// Field values come back as the types "encoding/json" decodes into "any"
+func FromJSON(data []byte) (error, error) {
+	var j *jsonError
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, err
+	}
+	if j == nil {
+		return nil, nil
+	}
+	if j.Code == "" && j.Message == "" && j.Errors == nil {
+		return nil, errNotPayload
+	}
+	return j.toError(), nil
+}
+
+// MarshalJSON implements "json.Marshaler"
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toJSON(e))
+}
+
+// UnmarshalJSON implements "json.Unmarshaler"
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var j jsonError
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*e = *j.toErrorValue()
+	return nil
+}
+
+// MarshalJSON implements "json.Marshaler"
+func (m *MultiError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toJSON(m))
+}
+
+// UnmarshalJSON implements "json.Unmarshaler"
+func (m *MultiError) UnmarshalJSON(data []byte) error {
+	var j jsonError
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	m.Errors = make([]error, len(j.Errors))
+	for i, e := range j.Errors {
+		m.Errors[i] = e.toError()
+	}
+	return nil
+}
+
+func toJSON(err error) *jsonError {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *Error:
+		j := &jsonError{Code: e.Code, Message: e.Message, Cause: toJSON(e.Cause)}
+		if len(e.Fields) > 0 {
+			j.Fields = make(map[string]any, len(e.Fields))
+			for _, f := range e.Fields {
+				j.Fields[f.Key] = jsonValue(f.Value)
+			}
+		}
+		return j
+	case *MultiError:
+		j := &jsonError{Errors: make([]*jsonError, len(e.Errors))}
+		for i, err := range e.Errors {
+			j.Errors[i] = toJSON(err)
+		}
+		return j
+	}
+	return &jsonError{Message: err.Error()}
+}
+
+// jsonValue replaces values that "encoding/json" cannot encode by their text
+func jsonValue(v any) any {
+	if err, ok := v.(error); ok {
+		return err.Error()
+	}
+	if _, err := json.Marshal(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return v
+}
+
+func (j *jsonError) toError() error {
+	switch {
+	case j == nil:
+		return nil
+	case j.Errors != nil:
+		m := &MultiError{Errors: make([]error, len(j.Errors))}
+		for i, e := range j.Errors {
+			m.Errors[i] = e.toError()
+		}
+		return m
+	case j.Code == "":
+		return errors.New(j.Message)
+	}
+	return j.toErrorValue()
+}
+
+func (j *jsonError) toErrorValue() *Error {
+	e := &Error{Code: j.Code, Message: j.Message, Cause: j.Cause.toError()}
+	if e.Code == "" {
+		e.Code = Unknown
+	}
+
+	// Maps have no order, sort the keys to keep the result deterministic
+	keys := make([]string, 0, len(j.Fields))
+	for k := range j.Fields {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		e.Fields = append(e.Fields, Field{Key: k, Value: j.Fields[k]})
+	}
+	return e
+}
@@ -0,0 +1,52 @@
+package errs
+
+import (
+	"strings"
+)
+
+// List aggregates the errors of independent operations,
+// e.g. validating every field of a request instead of stopping at the first problem.
+// The zero value is an empty list ready to use
+type List struct {
+	errs []error
+}
+
+// Add appends "err" to the list, nil errors are ignored
+func (l *List) Add(err error) {
+	if err != nil {
+		l.errs = append(l.errs, err)
+	}
+}
+
+// Len returns the number of errors collected
+func (l *List) Len() int {
+	return len(l.errs)
+}
+
+// Err returns nil when nothing was collected, or a *MultiError holding the errors
+func (l *List) Err() error {
+	if len(l.errs) == 0 {
+		return nil
+	}
+	return &MultiError{Errors: append([]error(nil), l.errs...)}
+}
+
+// MultiError is an error made of several independent errors.
+// "errors.Is" and "errors.As" look into every one of them
+type MultiError struct {
+	Errors []error
+}
+
+// Error joins the messages of all the errors with "; "
+func (m *MultiError) Error() string {
+	msgs := make([]string, len(m.Errors))
+	for i, err := range m.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the aggregated errors
+func (m *MultiError) Unwrap() []error {
+	return m.Errors
+}
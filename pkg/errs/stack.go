@@ -0,0 +1,42 @@
+package errs
+
+import "runtime"
+
+// maxDepth is the number of frames captured for each error
+const maxDepth = 32
+
+// Frame is a single function call of a stack trace
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// stack holds program counters, which are cheap to capture
+// and only resolved to frames when a stack trace is printed
+type stack []uintptr
+
+// callers captures the stack of the caller of the exported constructor
+func callers() stack {
+	var pcs [maxDepth]uintptr
+
+	// Skip runtime.Callers, callers and the constructor itself
+	n := runtime.Callers(3, pcs[:])
+	return pcs[:n:n]
+}
+
+func (s stack) frames() []Frame {
+	if len(s) == 0 {
+		return nil
+	}
+
+	var frames []Frame
+	it := runtime.CallersFrames(s)
+	for {
+		f, more := it.Next()
+		frames = append(frames, Frame{Function: f.Function, File: f.File, Line: f.Line})
+		if !more {
+			return frames
+		}
+	}
+}
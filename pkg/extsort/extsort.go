@@ -0,0 +1,280 @@
+// Package extsort sorts newline-delimited text and CSV input that does not fit in memory.
+//
+// Records are read until the memory budget is used up, sorted in memory
+// and spilled to a temporary "run" file. Once the input is exhausted,
+// the runs are combined with a k-way merge driven by a heap.
+// When everything fits in the budget no temporary file is created at all.
+package extsort
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+)
+
+// Default values used for the zero fields of Options
+const (
+	DefaultMemoryBudget = 64 << 20
+	DefaultFanIn        = 128
+)
+
+// Options control how the input is split into records, compared and written
+type Options struct {
+	// MemoryBudget is the approximate number of bytes of records
+	// held in memory before a sorted run is spilled to disk
+	MemoryBudget int64
+
+	// FanIn is the maximum number of runs merged at once,
+	// more runs are merged in several passes to bound the number of open files
+	FanIn int
+
+	// TempDir is where the runs are written, the system default when empty
+	TempDir string
+
+	// CSV reads and writes records with "encoding/csv" instead of plain lines
+	CSV bool
+
+	// Header passes the first record through unsorted
+	Header bool
+
+	// Field selects the 1-based field used as the sort key, 0 means the whole record
+	Field int
+
+	// Separator splits a line into fields, or replaces "," for CSV input,
+	// in text mode a zero value splits on runs of white space
+	Separator rune
+
+	// Numeric compares keys as floating point numbers,
+	// keys that are not numbers sort before all numbers
+	Numeric bool
+
+	// Reverse sorts in descending order, equal records keep their input order
+	Reverse bool
+
+	// Unique keeps only the first record for each distinct key
+	Unique bool
+}
+
+// Sort reads all records from "r" and writes them to "w" in sorted order
+func Sort(r io.Reader, w io.Writer, opts Options) (err error) {
+	if opts.Field < 0 {
+		return fmt.Errorf("extsort: invalid field %d", opts.Field)
+	}
+	if opts.MemoryBudget <= 0 {
+		opts.MemoryBudget = DefaultMemoryBudget
+	}
+	if opts.FanIn < 2 {
+		opts.FanIn = DefaultFanIn
+	}
+
+	s := &sorter{opts: opts}
+	defer func() {
+		// Runs are only scratch space, they go away whether we succeeded or not
+		if cerr := s.cleanup(); err == nil {
+			err = cerr
+		}
+	}()
+
+	in := newRecordReader(r, opts)
+	out := newRecordWriter(w, opts)
+
+	if opts.Header {
+		header, err := in.read()
+		if errors.Is(err, io.EOF) {
+			return out.flush()
+		}
+		if err != nil {
+			return err
+		}
+		if err := out.write(header); err != nil {
+			return err
+		}
+	}
+
+	var (
+		batch []record
+		size  int64
+	)
+	for {
+		rec, err := in.read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		s.key(&rec)
+
+		batch = append(batch, rec)
+		size += rec.size()
+		if size >= opts.MemoryBudget {
+			if err := s.spill(batch); err != nil {
+				return err
+			}
+			batch, size = batch[:0], 0
+		}
+	}
+
+	// Everything fit in memory, there is no need to touch the disk
+	if len(s.runs) == 0 {
+		for _, rec := range s.sortBatch(batch) {
+			if err := out.write(rec); err != nil {
+				return err
+			}
+		}
+		return out.flush()
+	}
+
+	if len(batch) > 0 {
+		if err := s.spill(batch); err != nil {
+			return err
+		}
+	}
+
+	for len(s.runs) > opts.FanIn {
+		if err := s.mergePass(); err != nil {
+			return err
+		}
+	}
+	if err := s.merge(s.runs, out); err != nil {
+		return err
+	}
+	return out.flush()
+}
+
+// SortFile sorts the file at "src" into the file at "dst", which may be the same file
+func SortFile(src, dst string, opts Options) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if opts.TempDir == "" {
+		opts.TempDir = os.TempDir()
+	}
+	tmp, err := os.CreateTemp(opts.TempDir, "extsort-out-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := Sort(in, tmp, opts); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return moveFile(tmp.Name(), dst)
+}
+
+// moveFile renames "src" to "dst", falling back to a copy
+// when they live on different file systems
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+type sorter struct {
+	opts Options
+	runs []string
+}
+
+// sortBatch sorts the records in place and drops duplicates when asked to
+func (s *sorter) sortBatch(batch []record) []record {
+	slices.SortStableFunc(batch, s.compare)
+	if s.opts.Unique {
+		batch = slices.CompactFunc(batch, func(a, b record) bool {
+			return s.compare(a, b) == 0
+		})
+	}
+	return batch
+}
+
+// spill sorts "batch" and writes it to a new run file
+func (s *sorter) spill(batch []record) error {
+	f, err := os.CreateTemp(s.opts.TempDir, "extsort-run-*")
+	if err != nil {
+		return err
+	}
+	s.runs = append(s.runs, f.Name())
+
+	w := newRecordWriter(f, s.opts)
+	for _, rec := range s.sortBatch(batch) {
+		if err := w.write(rec); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// mergePass merges consecutive groups of at most FanIn runs into new runs,
+// keeping groups in input order so that the merge stays stable
+func (s *sorter) mergePass() error {
+	var next []string
+	for start := 0; start < len(s.runs); start += s.opts.FanIn {
+		group := s.runs[start:min(start+s.opts.FanIn, len(s.runs))]
+
+		f, err := os.CreateTemp(s.opts.TempDir, "extsort-run-*")
+		if err != nil {
+			return err
+		}
+		next = append(next, f.Name())
+
+		w := newRecordWriter(f, s.opts)
+		err = s.merge(group, w)
+		if err == nil {
+			err = w.flush()
+		}
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			s.runs = append(s.runs, next...)
+			return err
+		}
+
+		for _, name := range group {
+			os.Remove(name)
+		}
+	}
+	s.runs = next
+	return nil
+}
+
+// cleanup removes every run that is still on disk
+func (s *sorter) cleanup() error {
+	var errs []error
+	for _, name := range s.runs {
+		if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	s.runs = nil
+	return errors.Join(errs...)
+}
@@ -0,0 +1,130 @@
+package extsort
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// lines returns "n" lines "key seq" with few distinct keys, so that ties are common
+// and the sequence number shows whether equal keys kept their input order
+func lines(n int) []string {
+	r := rand.New(rand.NewPCG(1, 2))
+	out := make([]string, n)
+	for i := range out {
+		out[i] = fmt.Sprintf("k%02d %d", r.IntN(20), i)
+	}
+	return out
+}
+
+func key(line string) string {
+	k, _, _ := strings.Cut(line, " ")
+	return k
+}
+
+// reference sorts the lines in memory the way Sort should with Field 1
+func reference(in []string, reverse, unique bool) []string {
+	out := slices.Clone(in)
+	slices.SortStableFunc(out, func(a, b string) int {
+		if reverse {
+			return cmp.Compare(key(b), key(a))
+		}
+		return cmp.Compare(key(a), key(b))
+	})
+	if unique {
+		out = slices.CompactFunc(out, func(a, b string) bool { return key(a) == key(b) })
+	}
+	return out
+}
+
+func sortLines(t *testing.T, in []string, opts Options) []string {
+	t.Helper()
+	var out bytes.Buffer
+	if err := Sort(strings.NewReader(strings.Join(in, "\n")+"\n"), &out, opts); err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+}
+
+func TestSortSpills(t *testing.T) {
+	in := lines(500)
+	for _, tt := range []struct {
+		name            string
+		reverse, unique bool
+	}{
+		{"stable", false, false},
+		{"reverse", true, false},
+		{"unique", false, true},
+		{"reverse unique", true, true},
+	} {
+		for _, budget := range []int64{1, 256, 4096, 0} {
+			t.Run(fmt.Sprintf("%s/budget=%d", tt.name, budget), func(t *testing.T) {
+				dir := t.TempDir()
+				opts := Options{MemoryBudget: budget, FanIn: 2, TempDir: dir, Field: 1, Reverse: tt.reverse, Unique: tt.unique}
+
+				got := sortLines(t, in, opts)
+				if want := reference(in, tt.reverse, tt.unique); !slices.Equal(got, want) {
+					t.Errorf("got %d lines, want %d\nfirst: %q\nwant:  %q", len(got), len(want), got[:5], want[:5])
+				}
+
+				// Runs are removed once merged
+				if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+					t.Errorf("%d files left in the temporary directory", len(entries))
+				}
+			})
+		}
+	}
+}
+
+func TestSortNumeric(t *testing.T) {
+	in := []string{"10", "9", "x", "-1", "1e2", "9", "abc"}
+	got := sortLines(t, in, Options{Numeric: true, MemoryBudget: 1, FanIn: 2, TempDir: t.TempDir()})
+	want := []string{"abc", "x", "-1", "9", "9", "10", "1e2"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSortCSV(t *testing.T) {
+	in := "name,age\n\"Smith, Jo\",40\nAnn,31\nBob,40\nCy,9\n"
+	var out bytes.Buffer
+	opts := Options{CSV: true, Header: true, Field: 2, Numeric: true, Reverse: true, MemoryBudget: 1, FanIn: 2, TempDir: t.TempDir()}
+	if err := Sort(strings.NewReader(in), &out, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "name,age\n\"Smith, Jo\",40\nBob,40\nAnn,31\nCy,9\n"
+	if out.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestSortFileInPlace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.txt")
+	if err := os.WriteFile(path, []byte("c\na\nb\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SortFile(path, path, Options{TempDir: t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "a\nb\nc\n" {
+		t.Errorf("got %q", data)
+	}
+}
+
+func TestSortInvalidField(t *testing.T) {
+	if err := Sort(strings.NewReader("a\n"), &bytes.Buffer{}, Options{Field: -1}); err == nil {
+		t.Error("expected an error for a negative field")
+	}
+}
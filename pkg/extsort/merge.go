@@ -0,0 +1,114 @@
+package extsort
+
+import (
+	"container/heap"
+	"errors"
+	"io"
+	"os"
+)
+
+// merge performs a k-way merge of the sorted runs into "out"
+func (s *sorter) merge(runs []string, out recordWriter) error {
+	h := &mergeHeap{sorter: s}
+
+	for i, name := range runs {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		src := &mergeSource{index: i, in: newRecordReader(f, s.opts)}
+		ok, err := s.advance(src)
+		if err != nil {
+			return err
+		}
+		if ok {
+			h.sources = append(h.sources, src)
+		}
+	}
+	heap.Init(h)
+
+	var (
+		last    record
+		written bool
+	)
+	for h.Len() > 0 {
+		src := h.sources[0]
+
+		// Records with the same key in a later run are duplicates of an earlier one
+		if !s.opts.Unique || !written || s.compare(last, src.rec) != 0 {
+			if err := out.write(src.rec); err != nil {
+				return err
+			}
+			last, written = src.rec, true
+		}
+
+		ok, err := s.advance(src)
+		if err != nil {
+			return err
+		}
+		if ok {
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+	return nil
+}
+
+// advance reads the next record of "src", reporting false once the run is exhausted
+func (s *sorter) advance(src *mergeSource) (bool, error) {
+	rec, err := src.in.read()
+	if errors.Is(err, io.EOF) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	s.key(&rec)
+	src.rec = rec
+	return true, nil
+}
+
+// mergeSource is the current head of one run
+type mergeSource struct {
+	index int
+	in    recordReader
+	rec   record
+}
+
+// mergeHeap implements "heap.Interface" over the heads of the runs,
+// ties are broken by run index so that earlier input wins
+type mergeHeap struct {
+	sorter  *sorter
+	sources []*mergeSource
+}
+
+func (h *mergeHeap) Len() int {
+	return len(h.sources)
+}
+
+func (h *mergeHeap) Less(i, j int) bool {
+	a, b := h.sources[i], h.sources[j]
+	if c := h.sorter.compare(a.rec, b.rec); c != 0 {
+		return c < 0
+	}
+	return a.index < b.index
+}
+
+func (h *mergeHeap) Swap(i, j int) {
+	h.sources[i], h.sources[j] = h.sources[j], h.sources[i]
+}
+
+func (h *mergeHeap) Push(x any) {
+	h.sources = append(h.sources, x.(*mergeSource))
+}
+
+func (h *mergeHeap) Pop() any {
+	old := h.sources
+	n := len(old)
+	src := old[n-1]
+	h.sources = old[:n-1]
+	return src
+}
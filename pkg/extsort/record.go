@@ -0,0 +1,178 @@
+package extsort
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// record is a single line, or a single row of CSV, together with its sort key
+type record struct {
+	fields []string
+	key    string
+	num    float64
+	isNum  bool
+}
+
+// size approximates the memory held by the record
+func (r record) size() int64 {
+	n := int64(len(r.key)) + 64
+	for _, f := range r.fields {
+		n += int64(len(f)) + 16
+	}
+	return n
+}
+
+// key extracts the sort key of "rec" according to the options
+func (s *sorter) key(rec *record) {
+	switch {
+	case s.opts.Field == 0 && len(rec.fields) == 1:
+		rec.key = rec.fields[0]
+	case s.opts.Field == 0:
+		// Compare CSV rows field by field
+		rec.key = strings.Join(rec.fields, "\x00")
+	case s.opts.CSV:
+		if s.opts.Field <= len(rec.fields) {
+			rec.key = rec.fields[s.opts.Field-1]
+		}
+	default:
+		rec.key = field(rec.fields[0], s.opts.Field, s.opts.Separator)
+	}
+
+	if s.opts.Numeric {
+		n, err := strconv.ParseFloat(strings.TrimSpace(rec.key), 64)
+		rec.num, rec.isNum = n, err == nil
+	}
+}
+
+// field returns the 1-based field "n" of "line", or an empty string when it is missing
+func field(line string, n int, sep rune) string {
+	var fields []string
+	if sep == 0 {
+		fields = strings.Fields(line)
+	} else {
+		fields = strings.Split(line, string(sep))
+	}
+
+	if n > len(fields) {
+		return ""
+	}
+	return fields[n-1]
+}
+
+// compare orders two records by their keys
+func (s *sorter) compare(a, b record) int {
+	var r int
+	switch {
+	case !s.opts.Numeric:
+		r = strings.Compare(a.key, b.key)
+	case a.isNum && b.isNum:
+		r = cmp.Compare(a.num, b.num)
+	case a.isNum:
+		r = 1
+	case b.isNum:
+		r = -1
+	default:
+		r = strings.Compare(a.key, b.key)
+	}
+
+	if s.opts.Reverse {
+		return -r
+	}
+	return r
+}
+
+type recordReader interface {
+	read() (record, error)
+}
+
+type recordWriter interface {
+	write(rec record) error
+	flush() error
+}
+
+func newRecordReader(r io.Reader, opts Options) recordReader {
+	if opts.CSV {
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = -1
+		if opts.Separator != 0 {
+			cr.Comma = opts.Separator
+		}
+		return csvReader{cr}
+	}
+	return lineReader{bufio.NewReaderSize(r, 64<<10)}
+}
+
+func newRecordWriter(w io.Writer, opts Options) recordWriter {
+	if opts.CSV {
+		cw := csv.NewWriter(w)
+		if opts.Separator != 0 {
+			cw.Comma = opts.Separator
+		}
+		return csvWriter{cw}
+	}
+	return lineWriter{bufio.NewWriterSize(w, 64<<10)}
+}
+
+type lineReader struct {
+	r *bufio.Reader
+}
+
+func (l lineReader) read() (record, error) {
+	line, err := l.r.ReadString('\n')
+
+	// The last line may not end with a newline
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	if err != nil {
+		return record{}, err
+	}
+
+	line = strings.TrimSuffix(line, "\n")
+	line = strings.TrimSuffix(line, "\r")
+	return record{fields: []string{line}}, nil
+}
+
+type lineWriter struct {
+	w *bufio.Writer
+}
+
+func (l lineWriter) write(rec record) error {
+	if _, err := l.w.WriteString(rec.fields[0]); err != nil {
+		return err
+	}
+	return l.w.WriteByte('\n')
+}
+
+func (l lineWriter) flush() error {
+	return l.w.Flush()
+}
+
+type csvReader struct {
+	r *csv.Reader
+}
+
+func (c csvReader) read() (record, error) {
+	fields, err := c.r.Read()
+	if err != nil {
+		return record{}, err
+	}
+	return record{fields: fields}, nil
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c csvWriter) write(rec record) error {
+	return c.w.Write(rec.fields)
+}
+
+func (c csvWriter) flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
@@ -0,0 +1,275 @@
+package id
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSequence(t *testing.T) {
+	s := NewSequence(10)
+	if s.Next() != 10 || s.Next() != 11 || s.Last() != 11 {
+		t.Error("NewSequence(10)")
+	}
+	next := Counter()
+	if next() != 1 || next() != 2 {
+		t.Error("Counter starts at 1")
+	}
+}
+
+func TestULIDText(t *testing.T) {
+	var max, spec ULID
+	for i := range max {
+		max[i] = 0xff
+	}
+	// The timestamp of the example in the ULID specification
+	putMillis(&spec, 1469922850259)
+	tests := []struct {
+		ulid ULID
+		text string
+	}{
+		{ULID{}, "00000000000000000000000000"},
+		{max, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ"},
+		{ULID{15: 1}, "00000000000000000000000001"},
+		{spec, "01ARZ3NDEK0000000000000000"},
+	}
+	for _, tt := range tests {
+		if got := tt.ulid.String(); got != tt.text {
+			t.Errorf("String() = %s, want %s", got, tt.text)
+		}
+		parsed, err := ParseULID(strings.ToLower(tt.text))
+		if err != nil || parsed != tt.ulid {
+			t.Errorf("ParseULID(%s) = %v, %v", tt.text, parsed, err)
+		}
+	}
+
+	if got := spec.Time(); got.UnixMilli() != 1469922850259 {
+		t.Errorf("Time() = %d", got.UnixMilli())
+	}
+}
+
+func TestParseULIDInvalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"01ARZ3NDEKTSV4RRFFQ69G5FA",   // too short
+		"01ARZ3NDEKTSV4RRFFQ69G5FAVX", // too long
+		"81ARZ3NDEKTSV4RRFFQ69G5FAV",  // more than 128 bits
+		"01ARZ3NDEKTSV4RRFFQ69G5FAU",  // U is not in the alphabet
+		"01ARZ3NDEKTSV4RRFFQ69G5FA!",
+		"01ARZ3NDEKTSV4RRFFQ69G5 AV",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAé",
+	} {
+		if u, err := ParseULID(s); err == nil {
+			t.Errorf("ParseULID(%q) = %v, expected an error", s, u)
+		}
+	}
+}
+
+func TestULIDRoundTrip(t *testing.T) {
+	for range 1000 {
+		u, err := NewULID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		text, _ := u.MarshalText()
+		var back ULID
+		if err := back.UnmarshalText(text); err != nil || back != u {
+			t.Fatalf("%s: round trip gave %s, %v", text, back, err)
+		}
+	}
+}
+
+func TestULIDMonotonic(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+	g := NewULIDGenerator(bytes.NewReader(bytes.Repeat([]byte{0xff}, 10)))
+	g.Now = func() time.Time { return now }
+
+	// The random part is all ones, the next ULID in the same millisecond overflows
+	if _, err := g.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Next(); !errors.Is(err, ErrMonotonicOverflow) {
+		t.Errorf("expected an overflow, got %v", err)
+	}
+
+	g = NewULIDGenerator(nil)
+	g.Now = func() time.Time { return now }
+	first, _ := g.Next()
+	second, _ := g.Next()
+	now = now.Add(-time.Second) // the clock goes back
+	third, _ := g.Next()
+	if first.String() >= second.String() || second.String() >= third.String() {
+		t.Errorf("not increasing: %s %s %s", first, second, third)
+	}
+}
+
+func TestUUID(t *testing.T) {
+	v4, err := NewV4()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v4.Version() != 4 || v4[8]&0xc0 != 0x80 {
+		t.Errorf("%s: version %d, variant %x", v4, v4.Version(), v4[8]>>6)
+	}
+	if _, ok := v4.Time(); ok {
+		t.Error("a version 4 UUID has no time")
+	}
+
+	at := time.UnixMilli(1_700_000_000_123)
+	v7, _ := newV7(at)
+	if ts, ok := v7.Time(); !ok || !ts.Equal(at) || v7.Version() != 7 {
+		t.Errorf("%s: time %v, version %d", v7, ts, v7.Version())
+	}
+	later, _ := newV7(at.Add(time.Millisecond))
+	if v7.String() >= later.String() {
+		t.Error("version 7 UUIDs do not sort by time")
+	}
+}
+
+func TestParseUUID(t *testing.T) {
+	const canonical = "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+	want := UUID{0xf8, 0x1d, 0x4f, 0xae, 0x7d, 0xec, 0x11, 0xd0, 0xa7, 0x65, 0x00, 0xa0, 0xc9, 0x1e, 0x6b, 0xf6}
+	for _, s := range []string{
+		canonical,
+		strings.ToUpper(canonical),
+		"{" + canonical + "}",
+		"urn:uuid:" + canonical,
+		strings.ReplaceAll(canonical, "-", ""),
+	} {
+		u, err := ParseUUID(s)
+		if err != nil || u != want {
+			t.Errorf("ParseUUID(%s) = %v, %v", s, u, err)
+		}
+	}
+	if want.String() != canonical || want.Version() != 1 {
+		t.Errorf("String() = %s", want)
+	}
+
+	for _, s := range []string{"", "f81d4fae7dec-11d0-a765-00a0c91e6bf6x", "f81d4fae-7dec-11d0-a765-00a0c91e6bfg", "{" + canonical, "f81d4fae-7dec-11d0-a76500-a0c91e6bf6"} {
+		if _, err := ParseUUID(s); err == nil {
+			t.Errorf("ParseUUID(%q) should fail", s)
+		}
+	}
+
+	for range 100 {
+		u, _ := NewV7()
+		text, _ := u.MarshalText()
+		var back UUID
+		if err := back.UnmarshalText(text); err != nil || back != u {
+			t.Fatalf("%s: round trip gave %s, %v", text, back, err)
+		}
+	}
+}
+
+func TestSnowflakeParts(t *testing.T) {
+	now := DefaultEpoch.Add(1234 * time.Second)
+	s, err := NewSnowflake(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Now = func() time.Time { return now }
+
+	a, _ := s.Next()
+	b, _ := s.Next()
+	parts := s.Parse(b)
+	if !parts.Time.Equal(now) || parts.Node != 42 || parts.Sequence != 1 || b <= a {
+		t.Errorf("Parse = %+v", parts)
+	}
+
+	if _, err := NewSnowflake(MaxNode + 1); err == nil {
+		t.Error("node out of range")
+	}
+}
+
+func TestSnowflakeClock(t *testing.T) {
+	now := DefaultEpoch.Add(time.Hour)
+	s, _ := NewSnowflake(1)
+	s.Now = func() time.Time { return now }
+	s.Sleep = func(d time.Duration) { now = now.Add(d) }
+
+	// The 4097th ID of a millisecond waits for the next one
+	var last int64
+	for range maxSequence + 2 {
+		id, err := s.Next()
+		if err != nil || id <= last {
+			t.Fatalf("id %d after %d: %v", id, last, err)
+		}
+		last = id
+	}
+	if parts := s.Parse(last); parts.Sequence != 0 || !parts.Time.Equal(DefaultEpoch.Add(time.Hour+time.Millisecond)) {
+		t.Errorf("after the sequence ran out: %+v", parts)
+	}
+
+	// A small step back is waited out, a big one fails
+	now = now.Add(-5 * time.Millisecond)
+	if id, err := s.Next(); err != nil || id <= last {
+		t.Errorf("small drift: %d, %v", id, err)
+	}
+	now = now.Add(-time.Second)
+	if _, err := s.Next(); !errors.Is(err, ErrClockMovedBackwards) {
+		t.Errorf("big drift: %v", err)
+	}
+}
+
+// TestConcurrentUnique draws IDs from many goroutines sharing the generators:
+// every ID must be unique, and the IDs each goroutine gets must increase
+func TestConcurrentUnique(t *testing.T) {
+	const goroutines, perGoroutine = 32, 2000
+
+	snowflake, _ := NewSnowflake(7)
+	ulids := NewULIDGenerator(nil)
+
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		snowflakes = make(map[int64]bool)
+		seen       = make(map[ULID]bool)
+	)
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var (
+				lastID   int64
+				lastULID ULID
+				ids      = make([]int64, 0, perGoroutine)
+				us       = make([]ULID, 0, perGoroutine)
+			)
+			for range perGoroutine {
+				id, err := snowflake.Next()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				u, err := ulids.Next()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if id <= lastID || bytes.Compare(u[:], lastULID[:]) <= 0 {
+					t.Errorf("not increasing: %d after %d, %s after %s", id, lastID, u, lastULID)
+					return
+				}
+				lastID, lastULID = id, u
+				ids, us = append(ids, id), append(us, u)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for i := range ids {
+				if snowflakes[ids[i]] || seen[us[i]] {
+					t.Errorf("duplicate: %d or %s", ids[i], us[i])
+				}
+				snowflakes[ids[i]], seen[us[i]] = true, true
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(snowflakes) != goroutines*perGoroutine || len(seen) != goroutines*perGoroutine {
+		t.Errorf("%d Snowflake IDs and %d ULIDs, want %d", len(snowflakes), len(seen), goroutines*perGoroutine)
+	}
+}
@@ -0,0 +1,37 @@
+// Package id generates unique identifiers: a goroutine-safe counter
+// (the safe version of the "intSeq" closure from the closures example),
+// 64-bit Snowflake IDs, lexicographically sortable ULIDs, and UUIDs version 4 and 7.
+//
+// Every generator is safe for concurrent use.
+package id
+
+import "sync/atomic"
+
+// Sequence is a counter that can be shared between goroutines.
+// The zero value starts at 1
+type Sequence struct {
+	n atomic.Uint64
+}
+
+// NewSequence returns a sequence whose first value is "start"
+func NewSequence(start uint64) *Sequence {
+	s := new(Sequence)
+	s.n.Store(start - 1)
+	return s
+}
+
+// Next returns the next value of the sequence
+func (s *Sequence) Next() uint64 {
+	return s.n.Add(1)
+}
+
+// Last returns the value most recently returned by Next
+func (s *Sequence) Last() uint64 {
+	return s.n.Load()
+}
+
+// Counter returns a closure like "intSeq" from the closures example, but goroutine-safe
+func Counter() func() uint64 {
+	var s Sequence
+	return s.Next
+}
@@ -0,0 +1,139 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// The layout of a Snowflake ID, from the most significant bits:
+// one unused sign bit, 41 bits of milliseconds since the epoch,
+// 10 bits of node and 12 bits of sequence within the millisecond
+const (
+	nodeBits     = 10
+	sequenceBits = 12
+	timeBits     = 41
+
+	MaxNode     = 1<<nodeBits - 1
+	maxSequence = 1<<sequenceBits - 1
+	maxTime     = 1<<timeBits - 1
+)
+
+// DefaultEpoch is the start of the Snowflake clock, 41 bits of milliseconds last about 69 years
+var DefaultEpoch = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// ErrClockMovedBackwards is returned when the clock goes back further than the tolerated drift
+var ErrClockMovedBackwards = errors.New("id: clock moved backwards")
+
+// Snowflake generates 64-bit IDs that are unique across nodes
+// and roughly ordered by creation time
+type Snowflake struct {
+	// MaxDrift is how far back the clock may jump before Next fails,
+	// smaller regressions are waited out. Defaults to 10 milliseconds
+	MaxDrift time.Duration
+
+	// Now returns the current time, it can be replaced to control the clock in tests
+	Now func() time.Time
+
+	// Sleep waits, it can be replaced along with Now
+	Sleep func(time.Duration)
+
+	mu       sync.Mutex
+	epoch    time.Time
+	node     int64
+	lastTime int64
+	sequence int64
+}
+
+// SnowflakeParts are the fields packed in a Snowflake ID
+type SnowflakeParts struct {
+	Time     time.Time
+	Node     int64
+	Sequence int64
+}
+
+// NewSnowflake returns a generator for the given node, between 0 and MaxNode,
+// counting time from DefaultEpoch
+func NewSnowflake(node int64) (*Snowflake, error) {
+	return NewSnowflakeWithEpoch(node, DefaultEpoch)
+}
+
+// NewSnowflakeWithEpoch returns a generator for the given node counting time from "epoch"
+func NewSnowflakeWithEpoch(node int64, epoch time.Time) (*Snowflake, error) {
+	if node < 0 || node > MaxNode {
+		return nil, fmt.Errorf("id: node %d out of range [0, %d]", node, MaxNode)
+	}
+	return &Snowflake{
+		MaxDrift: 10 * time.Millisecond,
+		Now:      time.Now,
+		Sleep:    time.Sleep,
+		epoch:    epoch,
+		node:     node,
+		lastTime: -1,
+	}, nil
+}
+
+// Next returns a new ID.
+// Up to 4096 IDs are generated per millisecond, then Next waits for the next one.
+// When the clock goes backwards by at most MaxDrift, Next waits until it catches up,
+// otherwise it fails with ErrClockMovedBackwards rather than risk duplicates
+func (s *Snowflake) Next() (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.millis()
+	if now < s.lastTime {
+		behind := time.Duration(s.lastTime-now) * time.Millisecond
+		if behind > s.MaxDrift {
+			return 0, fmt.Errorf("%w by %v", ErrClockMovedBackwards, behind)
+		}
+		s.Sleep(behind)
+		now = s.waitAfter(s.lastTime - 1)
+	}
+	if now > maxTime {
+		return 0, errors.New("id: Snowflake timestamp overflow, the epoch is too old")
+	}
+
+	if now == s.lastTime {
+		s.sequence = (s.sequence + 1) & maxSequence
+		if s.sequence == 0 {
+			// The sequence is exhausted for this millisecond
+			now = s.waitAfter(s.lastTime)
+		}
+	} else {
+		s.sequence = 0
+	}
+	s.lastTime = now
+
+	return now<<(nodeBits+sequenceBits) | s.node<<sequenceBits | s.sequence, nil
+}
+
+// Parse splits an ID generated with the same epoch into its parts
+func (s *Snowflake) Parse(id int64) SnowflakeParts {
+	return ParseSnowflake(id, s.epoch)
+}
+
+// ParseSnowflake splits an ID generated with the given epoch into its parts
+func ParseSnowflake(id int64, epoch time.Time) SnowflakeParts {
+	ms := id >> (nodeBits + sequenceBits)
+	return SnowflakeParts{
+		Time:     epoch.Add(time.Duration(ms) * time.Millisecond),
+		Node:     id >> sequenceBits & MaxNode,
+		Sequence: id & maxSequence,
+	}
+}
+
+func (s *Snowflake) millis() int64 {
+	return s.Now().Sub(s.epoch).Milliseconds()
+}
+
+// waitAfter spins until the clock is past "last"
+func (s *Snowflake) waitAfter(last int64) int64 {
+	now := s.millis()
+	for now <= last {
+		s.Sleep(100 * time.Microsecond)
+		now = s.millis()
+	}
+	return now
+}
@@ -0,0 +1,173 @@
+package id
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// ULID is a 128-bit identifier made of a 48-bit millisecond timestamp followed by
+// 80 random bits, written as 26 characters of Crockford's base32.
+// Their text sorts in creation order
+type ULID [16]byte
+
+// crockford is the base32 alphabet of ULIDs, without I, L, O and U
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ErrMonotonicOverflow is returned when more ULIDs are requested in one millisecond
+// than the random part can count
+var ErrMonotonicOverflow = errors.New("id: monotonic ULID overflow")
+
+// ULIDGenerator generates ULIDs that are strictly increasing, even within the same
+// millisecond, by incrementing the random part of the previous ULID instead of drawing a new one
+type ULIDGenerator struct {
+	// Now returns the current time, it can be replaced to control the clock in tests
+	Now func() time.Time
+
+	mu      sync.Mutex
+	entropy io.Reader
+	last    ULID
+	lastMs  uint64
+}
+
+// NewULIDGenerator returns a generator drawing randomness from "entropy",
+// "crypto/rand" when nil
+func NewULIDGenerator(entropy io.Reader) *ULIDGenerator {
+	if entropy == nil {
+		entropy = rand.Reader
+	}
+	return &ULIDGenerator{Now: time.Now, entropy: entropy}
+}
+
+var defaultULID = NewULIDGenerator(nil)
+
+// NewULID returns a monotonic ULID from a shared generator
+func NewULID() (ULID, error) {
+	return defaultULID.Next()
+}
+
+// Next returns a ULID greater than every ULID previously returned by the generator
+func (g *ULIDGenerator) Next() (ULID, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(g.Now().UnixMilli())
+
+	// The clock did not move forward, or moved back: continue from the last ULID
+	if ms <= g.lastMs && g.lastMs != 0 {
+		u := g.last
+		for i := 15; i >= 6; i-- {
+			u[i]++
+			if u[i] != 0 {
+				g.last = u
+				return u, nil
+			}
+		}
+		return ULID{}, ErrMonotonicOverflow
+	}
+
+	var u ULID
+	putMillis(&u, ms)
+	if _, err := io.ReadFull(g.entropy, u[6:]); err != nil {
+		return ULID{}, fmt.Errorf("id: reading entropy: %w", err)
+	}
+	g.last, g.lastMs = u, ms
+	return u, nil
+}
+
+func putMillis(u *ULID, ms uint64) {
+	for i := 5; i >= 0; i-- {
+		u[i] = byte(ms)
+		ms >>= 8
+	}
+}
+
+// Time returns the timestamp of the ULID
+func (u ULID) Time() time.Time {
+	var ms uint64
+	for _, b := range u[:6] {
+		ms = ms<<8 | uint64(b)
+	}
+	return time.UnixMilli(int64(ms))
+}
+
+// String encodes the ULID as 26 characters, 5 bits each, the first one carrying only 3 bits
+func (u ULID) String() string {
+	var out [26]byte
+	// Read the 128 bits from the least significant end, 5 at a time
+	var acc uint16
+	bits := 0
+	i := 15
+	for pos := 25; pos >= 0; pos-- {
+		for bits < 5 && i >= 0 {
+			acc |= uint16(u[i]) << bits
+			bits += 8
+			i--
+		}
+		out[pos] = crockford[acc&31]
+		acc >>= 5
+		bits -= 5
+	}
+	return string(out[:])
+}
+
+// ParseULID decodes the 26-character text of a ULID, case-insensitively
+func ParseULID(s string) (ULID, error) {
+	var u ULID
+	if len(s) != 26 {
+		return u, fmt.Errorf("id: invalid ULID length %d: %q", len(s), s)
+	}
+	// 26 × 5 = 130 bits, the first character may only hold 3
+	if decodeCrockford(s[0]) > 7 {
+		return u, fmt.Errorf("id: ULID overflows 128 bits: %q", s)
+	}
+
+	var acc uint16
+	bits := 0
+	i := 15
+	for pos := 25; pos >= 0; pos-- {
+		v := decodeCrockford(s[pos])
+		if v < 0 {
+			return ULID{}, fmt.Errorf("id: invalid ULID character %q in %q", s[pos], s)
+		}
+		acc |= uint16(v) << bits
+		bits += 5
+		if bits >= 8 && i >= 0 {
+			u[i] = byte(acc)
+			acc >>= 8
+			bits -= 8
+			i--
+		}
+	}
+	return u, nil
+}
+
+func decodeCrockford(c byte) int {
+	if 'a' <= c && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	for i := 0; i < len(crockford); i++ {
+		if crockford[i] == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// MarshalText implements "encoding.TextMarshaler"
+func (u ULID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements "encoding.TextUnmarshaler"
+func (u *ULID) UnmarshalText(text []byte) error {
+	parsed, err := ParseULID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
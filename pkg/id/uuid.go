@@ -0,0 +1,129 @@
+package id
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// UUID is a 128-bit universally unique identifier as defined by RFC 9562
+type UUID [16]byte
+
+// Nil is the UUID with every bit set to zero
+var Nil UUID
+
+// NewV4 returns a random UUID
+func NewV4() (UUID, error) {
+	var u UUID
+	if _, err := rand.Read(u[:]); err != nil {
+		return Nil, err
+	}
+	u.setVersion(4)
+	return u, nil
+}
+
+// NewV7 returns a UUID starting with the current Unix time in milliseconds
+// followed by random bits, so that UUIDs sort roughly by creation time
+func NewV7() (UUID, error) {
+	return newV7(time.Now())
+}
+
+func newV7(now time.Time) (UUID, error) {
+	var u UUID
+	if _, err := rand.Read(u[6:]); err != nil {
+		return Nil, err
+	}
+	ms := uint64(now.UnixMilli())
+	for i := 5; i >= 0; i-- {
+		u[i] = byte(ms)
+		ms >>= 8
+	}
+	u.setVersion(7)
+	return u, nil
+}
+
+// setVersion stores the version in the high nibble of byte 6
+// and the RFC 9562 variant, binary 10, in the high bits of byte 8
+func (u *UUID) setVersion(v byte) {
+	u[6] = u[6]&0x0f | v<<4
+	u[8] = u[8]&0x3f | 0x80
+}
+
+// Version returns the version number of the UUID
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// Time returns the timestamp of a version 7 UUID, and false for other versions
+func (u UUID) Time() (time.Time, bool) {
+	if u.Version() != 7 {
+		return time.Time{}, false
+	}
+	var ms uint64
+	for _, b := range u[:6] {
+		ms = ms<<8 | uint64(b)
+	}
+	return time.UnixMilli(int64(ms)), true
+}
+
+// String returns the canonical form "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// ParseUUID decodes the canonical form, optionally wrapped in braces
+// or prefixed with "urn:uuid:", or 32 hex digits without dashes
+func ParseUUID(s string) (UUID, error) {
+	orig := s
+	switch {
+	case len(s) == 45 && s[:9] == "urn:uuid:":
+		s = s[9:]
+	case len(s) == 38 && s[0] == '{' && s[37] == '}':
+		s = s[1:37]
+	}
+
+	var u UUID
+	switch len(s) {
+	case 32:
+		if _, err := hex.Decode(u[:], []byte(s)); err != nil {
+			return Nil, fmt.Errorf("id: invalid UUID %q", orig)
+		}
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return Nil, fmt.Errorf("id: invalid UUID %q", orig)
+		}
+		digits := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+		if _, err := hex.Decode(u[:], []byte(digits)); err != nil {
+			return Nil, fmt.Errorf("id: invalid UUID %q", orig)
+		}
+	default:
+		return Nil, fmt.Errorf("id: invalid UUID length %d: %q", len(orig), orig)
+	}
+	return u, nil
+}
+
+// MarshalText implements "encoding.TextMarshaler"
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements "encoding.TextUnmarshaler"
+func (u *UUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
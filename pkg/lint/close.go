@@ -0,0 +1,147 @@
+package lint
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// UncheckedClose reports calls to a "Close() error" method whose error is dropped,
+// as a statement of its own or deferred. Closing a file that was written to is
+// where a write may fail for good, e.g. when the disk is full.
+// Two cases are left alone: deferred calls on values that cannot be written to,
+// like the body of an HTTP response or a file from "os.Open", and calls right before
+// returning another error, which matters more. Assign the error to "_" to drop it
+// on purpose, or defer "atomicfile.Close" to return it from the enclosing function
+var UncheckedClose = &analysis.Analyzer{
+	Name:     "uncheckedclose",
+	Doc:      "report calls to Close whose error is not checked",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runUncheckedClose,
+}
+
+func runUncheckedClose(pass *analysis.Pass) (any, error) {
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// The variables holding files opened read-only
+	readOnly := map[types.Object]bool{}
+	in.Preorder([]ast.Node{(*ast.AssignStmt)(nil)}, func(n ast.Node) {
+		assign := n.(*ast.AssignStmt)
+		if len(assign.Rhs) != 1 || len(assign.Lhs) == 0 {
+			return
+		}
+		if call, ok := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr); ok && isFunc(pass, call, "os", "Open") {
+			if id, ok := assign.Lhs[0].(*ast.Ident); ok {
+				readOnly[pass.TypesInfo.ObjectOf(id)] = true
+			}
+		}
+	})
+
+	filter := []ast.Node{(*ast.ExprStmt)(nil), (*ast.DeferStmt)(nil), (*ast.GoStmt)(nil)}
+	in.WithStack(filter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		var (
+			call     *ast.CallExpr
+			deferred bool
+		)
+		switch n := n.(type) {
+		case *ast.ExprStmt:
+			call, _ = ast.Unparen(n.X).(*ast.CallExpr)
+		case *ast.DeferStmt:
+			call, deferred = n.Call, true
+		case *ast.GoStmt:
+			call = n.Call
+		}
+		if call == nil {
+			return true
+		}
+
+		recv, ok := closeReceiver(pass, call)
+		switch {
+		case !ok:
+		case deferred && (!isWriter(pass.TypesInfo.TypeOf(recv)) || readOnly[objectOf(pass, recv)]):
+		case !deferred && returnsError(stack):
+		default:
+			pass.ReportRangef(call, "the error of %s.Close is not checked", types.ExprString(recv))
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// objectOf returns the variable "x" names, if it is an identifier
+func objectOf(pass *analysis.Pass, x ast.Expr) types.Object {
+	if id, ok := ast.Unparen(x).(*ast.Ident); ok {
+		return pass.TypesInfo.ObjectOf(id)
+	}
+	return nil
+}
+
+// returnsError reports whether the statement on top of "stack" is followed by
+// a return whose last result is not nil, the error the caller gets instead
+func returnsError(stack []ast.Node) bool {
+	if len(stack) < 2 {
+		return false
+	}
+	var list []ast.Stmt
+	switch parent := stack[len(stack)-2].(type) {
+	case *ast.BlockStmt:
+		list = parent.List
+	case *ast.CaseClause:
+		list = parent.Body
+	case *ast.CommClause:
+		list = parent.Body
+	}
+
+	for i, stmt := range list {
+		if stmt != stack[len(stack)-1] || i+1 == len(list) {
+			continue
+		}
+		ret, ok := list[i+1].(*ast.ReturnStmt)
+		if !ok || len(ret.Results) == 0 {
+			return false
+		}
+		id, ok := ret.Results[len(ret.Results)-1].(*ast.Ident)
+		return !ok || id.Name != "nil"
+	}
+	return false
+}
+
+// closeReceiver returns the value "call" closes, when it calls a method "Close() error"
+func closeReceiver(pass *analysis.Pass, call *ast.CallExpr) (ast.Expr, bool) {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Close" {
+		return nil, false
+	}
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Signature().Recv() == nil {
+		return nil, false
+	}
+
+	sig := fn.Signature()
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return nil, false
+	}
+	return sel.X, types.Identical(sig.Results().At(0).Type(), errorType)
+}
+
+var (
+	errorType  = types.Universe.Lookup("error").Type()
+	writerType = types.NewInterfaceType([]*types.Func{
+		types.NewFunc(0, nil, "Write", types.NewSignatureType(nil, nil, nil,
+			types.NewTuple(types.NewParam(0, nil, "p", types.NewSlice(types.Typ[types.Byte]))),
+			types.NewTuple(types.NewParam(0, nil, "n", types.Typ[types.Int]), types.NewParam(0, nil, "err", errorType)),
+			false)),
+	}, nil).Complete()
+)
+
+// isWriter reports whether values of type "t" implement "io.Writer"
+func isWriter(t types.Type) bool {
+	return t != nil && (types.Implements(t, writerType) || types.Implements(types.NewPointer(t), writerType))
+}
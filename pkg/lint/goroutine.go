@@ -0,0 +1,161 @@
+package lint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// GoroutineExit reports "go" statements whose function runs a loop that never ends:
+// a "for" without a condition, or a range over a ticker channel which is never closed,
+// with no return, break or goto out of it. Such a goroutine can only stop with the program.
+// Functions are followed when they are literals or declared in the same package
+var GoroutineExit = &analysis.Analyzer{
+	Name:     "goroutineexit",
+	Doc:      "report goroutines running a loop with no exit path",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runGoroutineExit,
+}
+
+func runGoroutineExit(pass *analysis.Pass) (any, error) {
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	decls := map[*types.Func]*ast.FuncDecl{}
+	in.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		decl := n.(*ast.FuncDecl)
+		if fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func); ok && decl.Body != nil {
+			decls[fn] = decl
+		}
+	})
+
+	in.Preorder([]ast.Node{(*ast.GoStmt)(nil)}, func(n ast.Node) {
+		var body *ast.BlockStmt
+		switch fun := ast.Unparen(n.(*ast.GoStmt).Call.Fun).(type) {
+		case *ast.FuncLit:
+			body = fun.Body
+		default:
+			if decl, ok := decls[typeutil.StaticCallee(pass.TypesInfo, n.(*ast.GoStmt).Call)]; ok {
+				body = decl.Body
+			}
+		}
+		if body == nil {
+			return
+		}
+
+		if loop := endlessLoop(pass, body); loop != nil {
+			pass.ReportRangef(n, "goroutine never exits: the loop at line %d has no return or break",
+				pass.Fset.Position(loop.Pos()).Line)
+		}
+	})
+	return nil, nil
+}
+
+// endlessLoop returns the first loop of "body" that never ends, outside of nested functions
+func endlessLoop(pass *analysis.Pass, body *ast.BlockStmt) ast.Stmt {
+	var loop ast.Stmt
+	labels := map[ast.Stmt]*ast.Ident{}
+	ast.Inspect(body, func(n ast.Node) bool {
+		if loop != nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.LabeledStmt:
+			labels[n.Stmt] = n.Label
+		case *ast.ForStmt:
+			if n.Cond == nil && !exits(n.Body, labels[n]) {
+				loop = n
+			}
+		case *ast.RangeStmt:
+			if isTicker(pass, n.X) && !exits(n.Body, labels[n]) {
+				loop = n
+			}
+		}
+		return true
+	})
+	return loop
+}
+
+// exits reports whether the body of a loop has a way out of it: a return, a goto,
+// a break of the loop, or a call ending the goroutine such as panic or os.Exit
+func exits(body *ast.BlockStmt, label *ast.Ident) bool {
+	found := false
+	var visit func(n ast.Node, nested bool) bool
+	visit = func(n ast.Node, nested bool) bool {
+		if found {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			found = true
+		case *ast.BranchStmt:
+			switch {
+			case n.Tok == token.GOTO:
+				found = true
+			case n.Tok == token.BREAK && n.Label == nil:
+				found = !nested
+			case n.Tok == token.BREAK:
+				found = label != nil && n.Label.Name == label.Name
+			}
+		case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+			// An unlabeled break inside these leaves them, not the loop
+			if !nested {
+				ast.Inspect(n, func(m ast.Node) bool {
+					return m == n || visit(m, true)
+				})
+				return false
+			}
+		case *ast.CallExpr:
+			found = isExitCall(n)
+		}
+		return !found
+	}
+	ast.Inspect(body, func(n ast.Node) bool { return visit(n, false) })
+	return found
+}
+
+// isExitCall reports whether "call" syntactically calls a function that does not return
+func isExitCall(call *ast.CallExpr) bool {
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		return fun.Name == "panic"
+	case *ast.SelectorExpr:
+		pkg, ok := fun.X.(*ast.Ident)
+		if !ok {
+			return false
+		}
+		switch pkg.Name + "." + fun.Sel.Name {
+		case "os.Exit", "runtime.Goexit", "log.Fatal", "log.Fatalf", "log.Fatalln", "log.Panic", "log.Panicf", "log.Panicln":
+			return true
+		}
+	}
+	return false
+}
+
+// isTicker reports whether "x" is the channel of a ticker, "time.Tick(d)" or "ticker.C"
+func isTicker(pass *analysis.Pass, x ast.Expr) bool {
+	switch x := ast.Unparen(x).(type) {
+	case *ast.CallExpr:
+		return isFunc(pass, x, "time", "Tick")
+	case *ast.SelectorExpr:
+		if x.Sel.Name != "C" {
+			return false
+		}
+		t := pass.TypesInfo.TypeOf(x.X)
+		if p, ok := t.(*types.Pointer); ok {
+			t = p.Elem()
+		}
+		named, ok := t.(*types.Named)
+		return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" &&
+			named.Obj().Name() == "Ticker"
+	}
+	return false
+}
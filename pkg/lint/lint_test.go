@@ -0,0 +1,126 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestTimeTick(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), TimeTick, "timetick")
+}
+
+func TestUncheckedClose(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), UncheckedClose, "uncheckedclose")
+}
+
+func TestGoroutineExit(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), GoroutineExit, "goroutineexit")
+}
+
+func TestRun(t *testing.T) {
+	cfg := &packages.Config{Mode: packages.LoadAllSyntax, Dir: filepath.Join("testdata", "src", "ignore")}
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	findings, err := Run([]*analysis.Analyzer{TimeTick}, pkgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The directives without the analyzer or without a reason do not count
+	var lines []int
+	for _, f := range findings {
+		lines = append(lines, f.Line)
+		if f.Analyzer != "timetick" || filepath.Base(f.File) != "ignore.go" || f.EndLine != f.Line || f.EndColumn <= f.Column {
+			t.Errorf("finding %+v", f)
+		}
+	}
+	if want := []int{6, 20, 25}; !slices.Equal(lines, want) {
+		t.Errorf("findings on lines %v, want %v", lines, want)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	findings := []Finding{{
+		Analyzer: "timetick",
+		File:     filepath.Join("cmd", "main.go"),
+		Line:     3, Column: 5, EndLine: 3, EndColumn: 9,
+		Message: "ticker",
+	}}
+
+	var text bytes.Buffer
+	if err := WriteText(&text, findings); err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join("cmd", "main.go") + ":3:5: ticker (timetick)\n"; text.String() != want {
+		t.Errorf("WriteText = %q, want %q", text.String(), want)
+	}
+
+	var empty bytes.Buffer
+	if err := WriteJSON(&empty, nil); err != nil || strings.TrimSpace(empty.String()) != "[]" {
+		t.Errorf("WriteJSON(nil) = %q, %v", empty.String(), err)
+	}
+	var js bytes.Buffer
+	if err := WriteJSON(&js, findings); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []Finding
+	if err := json.Unmarshal(js.Bytes(), &decoded); err != nil || !slices.Equal(decoded, findings) {
+		t.Errorf("WriteJSON round trip = %v, %v", decoded, err)
+	}
+
+	var sarif bytes.Buffer
+	if err := WriteSARIF(&sarif, findings, Project); err != nil {
+		t.Fatal(err)
+	}
+	var log struct {
+		Version string
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct{ ID string }
+				}
+			}
+			Results []struct {
+				RuleID    string
+				RuleIndex int
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI       string
+							URIBaseID string
+						}
+						Region struct{ StartLine, StartColumn int }
+					}
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(sarif.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	run := log.Runs[0]
+	result := run.Results[0]
+	loc := result.Locations[0].PhysicalLocation
+	if log.Version != "2.1.0" || len(run.Tool.Driver.Rules) != len(Project) ||
+		run.Tool.Driver.Rules[result.RuleIndex].ID != "timetick" ||
+		loc.ArtifactLocation.URI != "cmd/main.go" || loc.ArtifactLocation.URIBaseID != "%SRCROOT%" ||
+		loc.Region.StartLine != 3 || loc.Region.StartColumn != 5 {
+		t.Errorf("WriteSARIF =\n%s", sarif.String())
+	}
+
+	findings[0].Analyzer = "unknown"
+	if err := WriteSARIF(io.Discard, findings, Project); err == nil {
+		t.Error("a finding of an analyzer that was not run should fail")
+	}
+}
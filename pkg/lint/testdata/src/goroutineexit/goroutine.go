@@ -0,0 +1,111 @@
+package goroutineexit
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+func spin() {
+	go func() { // want `goroutine never exits: the loop at line 11 has no return or break`
+		for {
+			fmt.Println("tick")
+		}
+	}()
+}
+
+func worker(jobs <-chan int) {
+	for {
+		select {
+		case j := <-jobs:
+			fmt.Println(j)
+			break // leaves the select, not the loop
+		}
+	}
+}
+
+func startWorker(jobs <-chan int) {
+	go worker(jobs) // want `goroutine never exits: the loop at line 18`
+}
+
+func ticker(t *time.Ticker) {
+	go func() { // want `goroutine never exits`
+		for range t.C {
+			fmt.Println("tick")
+		}
+	}()
+}
+
+func tick() {
+	go func() { // want `goroutine never exits`
+		for now := range time.Tick(time.Second) {
+			fmt.Println(now)
+		}
+	}()
+}
+
+func labeled(jobs <-chan int, quit <-chan bool) {
+	go func() {
+	loop:
+		for {
+			select {
+			case j := <-jobs:
+				fmt.Println(j)
+			case <-quit:
+				break loop
+			}
+		}
+	}()
+}
+
+func returns(quit <-chan bool) {
+	go func() {
+		for {
+			if <-quit {
+				return
+			}
+		}
+	}()
+}
+
+func exits() {
+	go func() {
+		for {
+			os.Exit(1)
+		}
+	}()
+	go func() {
+		for {
+			panic("stop")
+		}
+	}()
+}
+
+func bounded(jobs <-chan int) {
+	go func() {
+		for j := range jobs {
+			fmt.Println(j)
+		}
+	}()
+	go func() {
+		for i := 0; i < 3; i++ {
+			fmt.Println(i)
+		}
+	}()
+	go func() {
+		for {
+			break
+		}
+	}()
+}
+
+// A loop in a nested function literal is not the loop of the goroutine
+func nested() {
+	go func() {
+		f := func() {
+			for {
+			}
+		}
+		_ = f
+	}()
+}
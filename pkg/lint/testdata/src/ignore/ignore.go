@@ -0,0 +1,26 @@
+package ignore
+
+import "time"
+
+func reported() {
+	_ = time.Tick(time.Second)
+}
+
+func sameLine() {
+	_ = time.Tick(time.Second) //lint:ignore timetick the program never stops
+}
+
+func lineBefore() {
+	//lint:ignore goroutineexit,timetick the program never stops
+	_ = time.Tick(time.Second)
+}
+
+func otherAnalyzer() {
+	//lint:ignore uncheckedclose the wrong analyzer
+	_ = time.Tick(time.Second)
+}
+
+func noReason() {
+	//lint:ignore timetick
+	_ = time.Tick(time.Second)
+}
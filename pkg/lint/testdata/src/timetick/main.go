@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// The ticker of main lives as long as the program anyway
+func main() {
+	for t := range time.Tick(time.Second) {
+		fmt.Println(t)
+		break
+	}
+
+	go func() {
+		<-time.Tick(time.Second) // want `the ticker of time.Tick cannot be stopped`
+	}()
+
+	limiter := time.NewTicker(time.Second)
+	defer limiter.Stop()
+	<-limiter.C
+}
+
+func poll() {
+	for range time.Tick(time.Minute) { // want `the ticker of time.Tick cannot be stopped, use time.NewTicker`
+		fmt.Println("poll")
+	}
+}
+
+type server struct{}
+
+// A method called main is not the main function
+func (server) main() {
+	_ = time.Tick(time.Millisecond) // want `cannot be stopped`
+}
+
+// Tick is not time.Tick
+func Tick(time.Duration) <-chan time.Time { return nil }
+
+func notTime() {
+	<-Tick(time.Second)
+	_ = poll
+	_ = server.main
+}
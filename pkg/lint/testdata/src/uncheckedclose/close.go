@@ -0,0 +1,80 @@
+package uncheckedclose
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+)
+
+func write(path string) {
+	f, _ := os.Create(path)
+	defer f.Close() // want `the error of f.Close is not checked`
+	f.WriteString("data")
+}
+
+func writeThenClose(path string) {
+	f, _ := os.Create(path)
+	f.WriteString("data")
+	f.Close() // want `the error of f.Close is not checked`
+}
+
+func goClose(w io.WriteCloser) {
+	go w.Close() // want `the error of w.Close is not checked`
+}
+
+// A file opened with os.Open is read-only, the error of its deferred Close does not matter
+func read(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+// The body of a response cannot be written to
+func get(url string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(io.Discard, resp.Body)
+	return err
+}
+
+// The error of Close matters less than the one being returned
+func failed(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString("data"); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Assigning the error to "_" drops it on purpose
+func explicit(f *os.File) {
+	_ = f.Close()
+}
+
+func checked(f *os.File) error {
+	if err := f.Close(); err != nil {
+		return errors.Join(errors.New("closing"), err)
+	}
+	return nil
+}
+
+type quiet struct{}
+
+// A Close without an error result is left alone
+func (quiet) Close() {}
+
+func noError(q quiet) {
+	q.Close()
+	defer q.Close()
+}
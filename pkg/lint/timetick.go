@@ -0,0 +1,62 @@
+package lint
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// TimeTick reports calls to "time.Tick" outside the body of "main.main".
+// Its ticker cannot be stopped: before Go 1.23 it was never garbage collected,
+// and it still fires for as long as a goroutine receives from it.
+// The goroutine refilling the bursty limiter of the rate limiting example
+// uses "time.NewTicker" instead, and stops it
+var TimeTick = &analysis.Analyzer{
+	Name:     "timetick",
+	Doc:      "report time.Tick tickers that cannot be stopped, outside of main",
+	URL:      "https://pkg.go.dev/time#Tick",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runTimeTick,
+}
+
+func runTimeTick(pass *analysis.Pass) (any, error) {
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	in.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push || !isFunc(pass, n.(*ast.CallExpr), "time", "Tick") {
+			return true
+		}
+		if inMain(pass, stack) {
+			return true
+		}
+		pass.ReportRangef(n, "the ticker of time.Tick cannot be stopped, use time.NewTicker and call its Stop method when done")
+		return true
+	})
+	return nil, nil
+}
+
+// inMain reports whether the innermost function of "stack" is "main" of a main package,
+// where the ticker lives as long as the program anyway
+func inMain(pass *analysis.Pass, stack []ast.Node) bool {
+	if pass.Pkg.Name() != "main" {
+		return false
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.FuncDecl:
+			return fn.Recv == nil && fn.Name.Name == "main"
+		}
+	}
+	return false
+}
+
+// isFunc reports whether "call" calls the package-level function "pkg.name"
+func isFunc(pass *analysis.Pass, call *ast.CallExpr, pkg, name string) bool {
+	fn := typeutil.StaticCallee(pass.TypesInfo, call)
+	return fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == pkg && fn.Name() == name &&
+		fn.Signature().Recv() == nil
+}
@@ -0,0 +1,148 @@
+// Package markdown reads the structure of the Markdown files of this repository:
+// their headings with the anchors GitHub gives them, and the table of contents
+// blocks that doctoc used to maintain
+package markdown
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Heading is an ATX heading such as "## Time Formatting / Parsing"
+type Heading struct {
+	Level  int
+	Text   string // as written, e.g. "Time Formatting / Parsing"
+	Anchor string // unique within the document, e.g. "time-formatting--parsing"
+	Line   int    // 1-based
+}
+
+var headingPattern = regexp.MustCompile(`^ {0,3}(#{1,6})[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`)
+
+// Headings returns the headings of a document, skipping fenced code blocks
+// where "#" starts shell comments. Setext headings, underlined with "=" or "-", are not supported
+func Headings(src []byte) []Heading {
+	var (
+		headings []Heading
+		fence    string
+		seen     = map[string]int{}
+	)
+	for i, line := range strings.Split(string(src), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if f := fenceOf(line); f != "" {
+			switch {
+			case fence == "":
+				fence = f
+			case strings.HasPrefix(f, fence):
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+
+		m := headingPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		anchor := Anchor(m[2])
+
+		// GitHub numbers the anchors of headings with the same text: "a", "a-1", "a-2"
+		if n, dup := seen[anchor]; dup {
+			seen[anchor] = n + 1
+			anchor += "-" + strconv.Itoa(n+1)
+		} else {
+			seen[anchor] = 0
+		}
+		headings = append(headings, Heading{Level: len(m[1]), Text: m[2], Anchor: anchor, Line: i + 1})
+	}
+	return headings
+}
+
+// fenceOf returns the fence that opens or closes a code block on the line, if any
+func fenceOf(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return ""
+	}
+	for _, c := range []string{"`", "~"} {
+		n := len(trimmed) - len(strings.TrimLeft(trimmed, c))
+		if n >= 3 {
+			return strings.Repeat(c, n)
+		}
+	}
+	return ""
+}
+
+var (
+	linkPattern = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+	tagPattern  = regexp.MustCompile(`<[^>]+>`)
+)
+
+// Anchor returns the anchor GitHub generates for a heading: the text without markup,
+// in lower case, keeping letters, digits, "-" and "_", with every space turned into "-".
+// Other characters are dropped, so "Time Formatting / Parsing" becomes
+// "time-formatting--parsing", "Exec'ing Processes" "execing-processes" and "If/Else" "ifelse"
+func Anchor(text string) string {
+	text = linkPattern.ReplaceAllString(text, "$1")
+	text = tagPattern.ReplaceAllString(text, "")
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case r == ' ':
+			b.WriteByte('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r):
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Files returns the README.md files under "root", skipping hidden and testdata directories
+func Files(root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() && path != root && (strings.HasPrefix(name, ".") || name == "testdata") {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && name == "README.md" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// ModuleFiles returns the README.md files of the Go module holding the current directory,
+// relative to the current directory
+func ModuleFiles() ([]string, error) {
+	out, err := exec.Command("go", "env", "GOMOD").Output()
+	if err != nil {
+		return nil, err
+	}
+	gomod := strings.TrimSpace(string(out))
+	if gomod == "" || gomod == os.DevNull {
+		return nil, errors.New("not inside a Go module, name the files to process")
+	}
+
+	files, err := Files(filepath.Dir(gomod))
+	wd, _ := os.Getwd()
+	for i, file := range files {
+		if rel, err := filepath.Rel(wd, file); err == nil {
+			files[i] = rel
+		}
+	}
+	return files, err
+}
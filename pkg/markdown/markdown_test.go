@@ -0,0 +1,143 @@
+package markdown
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAnchor(t *testing.T) {
+	tests := []struct {
+		text, want string
+	}{
+		{"Time Formatting / Parsing", "time-formatting--parsing"},
+		{"Exec'ing Processes", "execing-processes"},
+		{"If/Else", "ifelse"},
+		{"snake_case and kebab-case", "snake_case-and-kebab-case"},
+		{"[Go by Example](https://gobyexample.com)", "go-by-example"},
+		{"<code>main</code> Package", "main-package"},
+		{"  Ünïcode 世界  ", "ünïcode-世界"},
+	}
+	for _, tt := range tests {
+		if got := Anchor(tt.text); got != tt.want {
+			t.Errorf("Anchor(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestHeadings(t *testing.T) {
+	src := "# Title\r\n" +
+		"\n" +
+		"## Usage ##\n" +
+		"```sh\n" +
+		"# not a heading\n" +
+		"````\n" +
+		"    # indented code, not a heading either\n" +
+		"#no space\n" +
+		"### Usage\n" +
+		"~~~\n" +
+		"## inside a tilde fence\n" +
+		"~~~\n" +
+		"####### too deep\n" +
+		"   ## Usage\n"
+
+	got := Headings([]byte(src))
+	want := []Heading{
+		{1, "Title", "title", 1},
+		{2, "Usage", "usage", 3},
+		{3, "Usage", "usage-1", 9},
+		{2, "Usage", "usage-2", 14},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Headings =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestUpdateTOC(t *testing.T) {
+	src := "# Title\n\n" +
+		TOCStart + "\n" +
+		"- [stale](#stale)\n" +
+		TOCEnd + "\n\n" +
+		"## Table of Contents\n" +
+		"## [Basics](basics.md)\n" +
+		"### Hello World\n" +
+		"#### Too deep\n"
+	want := "# Title\n\n" +
+		TOCStart + "\n" +
+		tocNote + "\n\n" +
+		"- [Basics](#basics)\n" +
+		"  - [Hello World](#hello-world)\n\n" +
+		TOCEnd + "\n\n" +
+		"## Table of Contents\n" +
+		"## [Basics](basics.md)\n" +
+		"### Hello World\n" +
+		"#### Too deep\n"
+
+	got, err := UpdateTOC([]byte(src), TOCOptions{MaxLevel: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("UpdateTOC =\n%s\nwant\n%s", got, want)
+	}
+
+	again, _ := UpdateTOC(got, TOCOptions{MaxLevel: 3})
+	if string(again) != string(got) {
+		t.Error("UpdateTOC is not idempotent")
+	}
+
+	if _, err := UpdateTOC([]byte(TOCStart+"\n## A\n"), TOCOptions{}); err == nil {
+		t.Error("a block without its end marker should fail")
+	}
+}
+
+func TestFiles(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{
+		"README.md",
+		"a/README.md",
+		"a/b/README.md",
+		"a/notes.md",
+		".git/README.md",
+		"testdata/README.md",
+	} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := Files(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, f := range files {
+		files[i], _ = filepath.Rel(root, f)
+	}
+	want := []string{"README.md", filepath.Join("a", "README.md"), filepath.Join("a", "b", "README.md")}
+	if !slices.Equal(files, want) {
+		t.Errorf("Files = %v, want %v", files, want)
+	}
+}
+
+func TestModuleFiles(t *testing.T) {
+	// The tests run in pkg/markdown, the README files are found from the module root
+	files, err := ModuleFiles()
+	if err != nil {
+		t.Skip("go env GOMOD:", err)
+	}
+	root := filepath.Join("..", "..", "README.md")
+	if !slices.Contains(files, root) {
+		t.Errorf("ModuleFiles = %v, want it to contain %s", files, root)
+	}
+	for _, f := range files {
+		if filepath.IsAbs(f) || !strings.HasSuffix(f, "README.md") {
+			t.Errorf("%s is not a relative README path", f)
+		}
+	}
+}
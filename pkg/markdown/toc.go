@@ -0,0 +1,70 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The markers of the table of contents block, as written by doctoc
+const (
+	TOCStart = "<!-- START doctoc generated TOC please keep comment here to allow auto update -->"
+	TOCEnd   = "<!-- END doctoc generated TOC please keep comment here to allow auto update -->"
+	tocNote  = "<!-- DON'T EDIT THIS SECTION, INSTEAD RE-RUN doctoc TO UPDATE -->"
+)
+
+// TOCOptions selects the headings listed in a table of contents
+type TOCOptions struct {
+	MinLevel int // 2 when zero, the title of the document is left out
+	MaxLevel int // 6 when zero
+}
+
+// TOC returns the table of contents of the headings, a nested list of links
+// indented by two spaces per level. The heading of the table itself, "Table of Contents",
+// is left out
+func TOC(headings []Heading, opts TOCOptions) string {
+	if opts.MinLevel == 0 {
+		opts.MinLevel = 2
+	}
+	if opts.MaxLevel == 0 {
+		opts.MaxLevel = 6
+	}
+
+	var b strings.Builder
+	for _, h := range headings {
+		if h.Level < opts.MinLevel || h.Level > opts.MaxLevel || strings.EqualFold(h.Text, "Table of Contents") {
+			continue
+		}
+		text := linkPattern.ReplaceAllString(h.Text, "$1")
+		fmt.Fprintf(&b, "%s- [%s](#%s)\n", strings.Repeat("  ", h.Level-opts.MinLevel), text, h.Anchor)
+	}
+	return b.String()
+}
+
+// UpdateTOC rewrites every table of contents block of a document from its headings
+func UpdateTOC(src []byte, opts TOCOptions) ([]byte, error) {
+	lines := strings.SplitAfter(string(src), "\n")
+	toc := TOC(Headings(src), opts)
+
+	var out strings.Builder
+	for i := 0; i < len(lines); i++ {
+		out.WriteString(lines[i])
+		if strings.TrimRight(lines[i], "\r\n") != TOCStart {
+			continue
+		}
+
+		end := i + 1
+		for end < len(lines) && strings.TrimRight(lines[end], "\r\n") != TOCEnd {
+			end++
+		}
+		if end == len(lines) {
+			return nil, fmt.Errorf("%d: table of contents without its end marker", i+1)
+		}
+
+		out.WriteString(tocNote + "\n\n")
+		if toc != "" {
+			out.WriteString(toc + "\n")
+		}
+		i = end - 1
+	}
+	return []byte(out.String()), nil
+}
@@ -0,0 +1,152 @@
+package matrix
+
+import (
+	"iter"
+	"strings"
+)
+
+// Point is a cell position in a Grid, Row growing downwards and Col to the right
+type Point struct {
+	Row, Col int
+}
+
+// Add returns the position "p" moved by "d"
+func (p Point) Add(d Point) Point {
+	return Point{p.Row + d.Row, p.Col + d.Col}
+}
+
+// The four orthogonal directions, then the four diagonal ones
+var (
+	Up    = Point{-1, 0}
+	Down  = Point{1, 0}
+	Left  = Point{0, -1}
+	Right = Point{0, 1}
+
+	Orthogonal = []Point{Up, Right, Down, Left}
+	Diagonal   = []Point{{-1, -1}, {-1, 1}, {1, 1}, {1, -1}}
+	Around     = []Point{Up, {-1, 1}, Right, {1, 1}, Down, {1, -1}, Left, {-1, -1}}
+)
+
+// Grid is a rectangular array of cells of any type
+type Grid[T any] struct {
+	rows, cols int
+	cells      []T
+}
+
+// NewGrid returns a grid of the given size with zero cells
+func NewGrid[T any](rows, cols int) *Grid[T] {
+	if rows < 0 || cols < 0 {
+		panic("matrix: negative grid dimension")
+	}
+	return &Grid[T]{rows: rows, cols: cols, cells: make([]T, rows*cols)}
+}
+
+// ParseGrid builds a grid from lines of text, one cell per rune converted by "cell".
+// Shorter lines are padded with the zero value
+func ParseGrid[T any](text string, cell func(r rune) T) *Grid[T] {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+
+	cols := 0
+	for _, line := range lines {
+		cols = max(cols, len([]rune(line)))
+	}
+
+	g := NewGrid[T](len(lines), cols)
+	for i, line := range lines {
+		for j, r := range []rune(line) {
+			g.cells[i*cols+j] = cell(r)
+		}
+	}
+	return g
+}
+
+// Dims returns the number of rows and columns
+func (g *Grid[T]) Dims() (rows, cols int) {
+	return g.rows, g.cols
+}
+
+// In reports whether "p" is inside the grid
+func (g *Grid[T]) In(p Point) bool {
+	return 0 <= p.Row && p.Row < g.rows && 0 <= p.Col && p.Col < g.cols
+}
+
+// At returns the cell at "p", it panics when "p" is outside of the grid
+func (g *Grid[T]) At(p Point) T {
+	if !g.In(p) {
+		panic("matrix: point outside of the grid")
+	}
+	return g.cells[p.Row*g.cols+p.Col]
+}
+
+// Get returns the cell at "p", or the zero value and false when "p" is outside of the grid
+func (g *Grid[T]) Get(p Point) (T, bool) {
+	if !g.In(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[p.Row*g.cols+p.Col], true
+}
+
+// Set stores "v" at "p", it panics when "p" is outside of the grid
+func (g *Grid[T]) Set(p Point, v T) {
+	if !g.In(p) {
+		panic("matrix: point outside of the grid")
+	}
+	g.cells[p.Row*g.cols+p.Col] = v
+}
+
+// All iterates over every cell, row by row
+func (g *Grid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for i := 0; i < g.rows; i++ {
+			for j := 0; j < g.cols; j++ {
+				if !yield(Point{i, j}, g.cells[i*g.cols+j]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Neighbors iterates over the cells next to "p" in the given directions,
+// skipping those outside of the grid, e.g. Neighbors(p, Orthogonal)
+func (g *Grid[T]) Neighbors(p Point, directions []Point) iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for _, d := range directions {
+			q := p.Add(d)
+			if !g.In(q) {
+				continue
+			}
+			if !yield(q, g.cells[q.Row*g.cols+q.Col]) {
+				return
+			}
+		}
+	}
+}
+
+// Find returns the position of the first cell, row by row, that satisfies "f"
+func (g *Grid[T]) Find(f func(T) bool) (Point, bool) {
+	for p, v := range g.All() {
+		if f(v) {
+			return p, true
+		}
+	}
+	return Point{}, false
+}
+
+// Clone returns a copy of the grid
+func (g *Grid[T]) Clone() *Grid[T] {
+	return &Grid[T]{rows: g.rows, cols: g.cols, cells: append([]T(nil), g.cells...)}
+}
+
+// Format renders the grid one row per line, each cell converted by "cell"
+func (g *Grid[T]) Format(cell func(T) string) string {
+	var b strings.Builder
+	for i := 0; i < g.rows; i++ {
+		for j := 0; j < g.cols; j++ {
+			b.WriteString(cell(g.cells[i*g.cols+j]))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,139 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+// epsilon is the machine epsilon of float64, the gap between 1 and the next float64
+const epsilon = 0x1p-52
+
+// LU is the decomposition "P × A = L × U" of a square matrix A with partial pivoting,
+// L being unit lower triangular and U upper triangular
+type LU struct {
+	lu    *Matrix // L below the diagonal, U on and above it
+	pivot []int   // Row "i" of P × A is row pivot[i] of A
+	sign  float64 // Determinant of P, +1 or -1
+}
+
+// Decompose computes the LU decomposition of the square matrix "m".
+// It succeeds for singular matrices too, Det then returns 0 and Solve fails.
+// A pivot smaller than n·ε·max|a_ij| is rounding error left of a zero, and is taken as zero
+func Decompose(m *Matrix) (*LU, error) {
+	if m.rows != m.cols {
+		return nil, fmt.Errorf("%w: LU decomposition needs a square matrix, got %dx%d", ErrShape, m.rows, m.cols)
+	}
+
+	n := m.rows
+	a := m.Clone()
+	pivot := make([]int, n)
+	for i := range pivot {
+		pivot[i] = i
+	}
+	sign := 1.0
+
+	largest := 0.0
+	for _, v := range m.data {
+		largest = math.Max(largest, math.Abs(v))
+	}
+	tolerance := float64(n) * epsilon * largest
+
+	for k := 0; k < n; k++ {
+		// Pick the largest pivot in the column to keep the elimination stable
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(a.data[i*n+k]) > math.Abs(a.data[p*n+k]) {
+				p = i
+			}
+		}
+		if p != k {
+			for j := 0; j < n; j++ {
+				a.data[k*n+j], a.data[p*n+j] = a.data[p*n+j], a.data[k*n+j]
+			}
+			pivot[k], pivot[p] = pivot[p], pivot[k]
+			sign = -sign
+		}
+
+		d := a.data[k*n+k]
+		if math.Abs(d) <= tolerance {
+			// The rest of the column is no larger than the pivot, zero it all
+			for i := k; i < n; i++ {
+				a.data[i*n+k] = 0
+			}
+			continue
+		}
+		for i := k + 1; i < n; i++ {
+			f := a.data[i*n+k] / d
+			a.data[i*n+k] = f
+			for j := k + 1; j < n; j++ {
+				a.data[i*n+j] -= f * a.data[k*n+j]
+			}
+		}
+	}
+
+	return &LU{lu: a, pivot: pivot, sign: sign}, nil
+}
+
+// Det returns the determinant, the product of the diagonal of U times the sign of P
+func (d *LU) Det() float64 {
+	n := d.lu.rows
+	det := d.sign
+	for i := 0; i < n; i++ {
+		det *= d.lu.data[i*n+i]
+	}
+	return det
+}
+
+// Solve returns X such that "A × X = B", by forward then back substitution
+func (d *LU) Solve(b *Matrix) (*Matrix, error) {
+	n := d.lu.rows
+	if b.rows != n {
+		return nil, fmt.Errorf("%w: cannot solve %dx%d system with %dx%d right-hand side", ErrShape, n, n, b.rows, b.cols)
+	}
+	for i := 0; i < n; i++ {
+		if d.lu.data[i*n+i] == 0 {
+			return nil, ErrSingular
+		}
+	}
+
+	// Apply the row permutation to B
+	x := New(n, b.cols)
+	for i, p := range d.pivot {
+		copy(x.data[i*b.cols:(i+1)*b.cols], b.data[p*b.cols:(p+1)*b.cols])
+	}
+
+	for c := 0; c < b.cols; c++ {
+		// L × Y = P × B, L has ones on its diagonal
+		for i := 0; i < n; i++ {
+			for k := 0; k < i; k++ {
+				x.data[i*b.cols+c] -= d.lu.data[i*n+k] * x.data[k*b.cols+c]
+			}
+		}
+		// U × X = Y
+		for i := n - 1; i >= 0; i-- {
+			for k := i + 1; k < n; k++ {
+				x.data[i*b.cols+c] -= d.lu.data[i*n+k] * x.data[k*b.cols+c]
+			}
+			x.data[i*b.cols+c] /= d.lu.data[i*n+i]
+		}
+	}
+	return x, nil
+}
+
+// Det returns the determinant of the square matrix "m"
+func (m *Matrix) Det() (float64, error) {
+	d, err := Decompose(m)
+	if err != nil {
+		return 0, err
+	}
+	return d.Det(), nil
+}
+
+// Inverse returns the inverse of the square matrix "m" by solving "M × X = I"
+func (m *Matrix) Inverse() (*Matrix, error) {
+	d, err := Decompose(m)
+	if err != nil {
+		return nil, err
+	}
+	return d.Solve(Identity(m.rows))
+}
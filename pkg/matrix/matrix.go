@@ -0,0 +1,143 @@
+// Package matrix provides a dense matrix of float64 with multiplication,
+// LU decomposition, determinant and inverse, and a generic two-dimensional Grid
+// for puzzle-style problems, replacing the hand-built "twoD" arrays and slices
+// of the arrays and slices examples.
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrShape is returned when the dimensions of the operands do not match
+	ErrShape = errors.New("matrix: dimension mismatch")
+
+	// ErrSingular is returned when inverting or solving with a singular matrix
+	ErrSingular = errors.New("matrix: singular matrix")
+)
+
+// Matrix is a dense matrix stored in row-major order
+type Matrix struct {
+	rows, cols int
+	data       []float64
+}
+
+// New returns a zero matrix with the given dimensions
+func New(rows, cols int) *Matrix {
+	if rows < 0 || cols < 0 {
+		panic("matrix: negative dimension")
+	}
+	return &Matrix{rows: rows, cols: cols, data: make([]float64, rows*cols)}
+}
+
+// FromRows returns a matrix holding a copy of "rows", which must all have the same length
+func FromRows(rows [][]float64) (*Matrix, error) {
+	if len(rows) == 0 {
+		return New(0, 0), nil
+	}
+
+	m := New(len(rows), len(rows[0]))
+	for i, row := range rows {
+		if len(row) != m.cols {
+			return nil, fmt.Errorf("%w: row %d has %d columns, want %d", ErrShape, i, len(row), m.cols)
+		}
+		copy(m.data[i*m.cols:], row)
+	}
+	return m, nil
+}
+
+// Identity returns the "n" by "n" identity matrix
+func Identity(n int) *Matrix {
+	m := New(n, n)
+	for i := 0; i < n; i++ {
+		m.data[i*n+i] = 1
+	}
+	return m
+}
+
+// Dims returns the number of rows and columns
+func (m *Matrix) Dims() (rows, cols int) {
+	return m.rows, m.cols
+}
+
+// At returns the element at row "i" and column "j"
+func (m *Matrix) At(i, j int) float64 {
+	m.check(i, j)
+	return m.data[i*m.cols+j]
+}
+
+// Set stores "v" at row "i" and column "j"
+func (m *Matrix) Set(i, j int, v float64) {
+	m.check(i, j)
+	m.data[i*m.cols+j] = v
+}
+
+func (m *Matrix) check(i, j int) {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic(fmt.Sprintf("matrix: index (%d, %d) out of range for %dx%d", i, j, m.rows, m.cols))
+	}
+}
+
+// Row returns a copy of row "i"
+func (m *Matrix) Row(i int) []float64 {
+	m.check(i, 0)
+	return append([]float64(nil), m.data[i*m.cols:(i+1)*m.cols]...)
+}
+
+// Rows returns a copy of the matrix as a slice of rows
+func (m *Matrix) Rows() [][]float64 {
+	rows := make([][]float64, m.rows)
+	for i := range rows {
+		rows[i] = m.Row(i)
+	}
+	return rows
+}
+
+// Clone returns a copy of the matrix
+func (m *Matrix) Clone() *Matrix {
+	return &Matrix{rows: m.rows, cols: m.cols, data: append([]float64(nil), m.data...)}
+}
+
+// T returns the transpose of the matrix
+func (m *Matrix) T() *Matrix {
+	t := New(m.cols, m.rows)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			t.data[j*m.rows+i] = m.data[i*m.cols+j]
+		}
+	}
+	return t
+}
+
+// Equal reports whether "m" and "n" have the same dimensions
+// and their elements differ by at most "tolerance"
+func (m *Matrix) Equal(n *Matrix, tolerance float64) bool {
+	if m.rows != n.rows || m.cols != n.cols {
+		return false
+	}
+	for i, v := range m.data {
+		d := v - n.data[i]
+		if d > tolerance || d < -tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the matrix one row per line
+func (m *Matrix) String() string {
+	var b strings.Builder
+	for i := 0; i < m.rows; i++ {
+		b.WriteByte('[')
+		for j := 0; j < m.cols; j++ {
+			if j > 0 {
+				b.WriteByte(' ')
+			}
+			fmt.Fprintf(&b, "%g", m.data[i*m.cols+j])
+		}
+		b.WriteString("]\n")
+	}
+	return b.String()
+}
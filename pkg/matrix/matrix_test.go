@@ -0,0 +1,228 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func mustRows(t testing.TB, rows [][]float64) *Matrix {
+	t.Helper()
+	m, err := FromRows(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func random(rng *rand.Rand, rows, cols int) *Matrix {
+	m := New(rows, cols)
+	for i := range m.data {
+		m.data[i] = rng.Float64()*2 - 1
+	}
+	return m
+}
+
+func TestBasics(t *testing.T) {
+	m := mustRows(t, [][]float64{{1, 2, 3}, {4, 5, 6}})
+	if r, c := m.Dims(); r != 2 || c != 3 || m.At(1, 2) != 6 {
+		t.Errorf("Dims = %d, %d", r, c)
+	}
+	if got := m.T().Rows(); !slices.EqualFunc(got, [][]float64{{1, 4}, {2, 5}, {3, 6}}, slices.Equal) {
+		t.Errorf("T = %v", got)
+	}
+	if got := m.String(); got != "[1 2 3]\n[4 5 6]\n" {
+		t.Errorf("String = %q", got)
+	}
+
+	c := m.Clone()
+	c.Set(0, 0, 10)
+	row := m.Row(0)
+	row[1] = 20
+	if m.At(0, 0) != 1 || m.At(0, 1) != 2 {
+		t.Error("Clone and Row should copy")
+	}
+	if m.Equal(c, 1e-9) || !m.Equal(c, 9) || m.Equal(m.T(), 100) {
+		t.Error("Equal")
+	}
+
+	if _, err := FromRows([][]float64{{1, 2}, {3}}); !errors.Is(err, ErrShape) {
+		t.Errorf("ragged rows: %v", err)
+	}
+	if e, _ := FromRows(nil); e.rows != 0 || e.cols != 0 {
+		t.Error("FromRows(nil)")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("At out of range should panic")
+		}
+	}()
+	m.At(2, 0)
+}
+
+func TestMul(t *testing.T) {
+	a := mustRows(t, [][]float64{{1, 2}, {3, 4}, {5, 6}})
+	b := mustRows(t, [][]float64{{7, 8, 9}, {10, 11, 12}})
+	want := mustRows(t, [][]float64{{27, 30, 33}, {61, 68, 75}, {95, 106, 117}})
+
+	for name, mul := range map[string]func(*Matrix, *Matrix) (*Matrix, error){
+		"Mul":         (*Matrix).Mul,
+		"MulBlocked":  (*Matrix).MulBlocked,
+		"MulParallel": (*Matrix).MulParallel,
+	} {
+		if got, err := mul(a, b); err != nil || !got.Equal(want, 0) {
+			t.Errorf("%s =\n%v%v", name, got, err)
+		}
+		if _, err := mul(a, a); !errors.Is(err, ErrShape) {
+			t.Errorf("%s of 3x2 by 3x2: %v", name, err)
+		}
+	}
+}
+
+// TestMulSizes compares the three products on sizes around the tile edge,
+// where the blocked loops have partial tiles
+func TestMulSizes(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for _, dims := range [][3]int{{1, 1, 1}, {63, 64, 65}, {130, 70, 129}, {200, 1, 3}} {
+		a, b := random(rng, dims[0], dims[1]), random(rng, dims[1], dims[2])
+		want, _ := a.Mul(b)
+		blocked, _ := a.MulBlocked(b)
+		parallel, _ := a.MulParallel(b)
+		if !blocked.Equal(want, 1e-9) || !parallel.Equal(want, 1e-9) {
+			t.Errorf("%v: the products differ", dims)
+		}
+	}
+}
+
+func TestLU(t *testing.T) {
+	m := mustRows(t, [][]float64{{0, 2, 1}, {1, 1, 0}, {3, 0, 1}})
+	det, err := m.Det()
+	if err != nil || det != -5 {
+		t.Errorf("Det = %v, %v, want -5", det, err)
+	}
+
+	inv, err := m.Inverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if product, _ := m.Mul(inv); !product.Equal(Identity(3), 1e-12) {
+		t.Errorf("M × M⁻¹ =\n%v", product)
+	}
+
+	rng := rand.New(rand.NewPCG(3, 4))
+	big := random(rng, 50, 50)
+	d, err := Decompose(big)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := random(rng, 50, 2)
+	x, err := d.Solve(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ax, _ := big.Mul(x); !ax.Equal(b, 1e-9) {
+		t.Error("A × X != B")
+	}
+	if _, err := d.Solve(New(49, 1)); !errors.Is(err, ErrShape) {
+		t.Errorf("Solve with the wrong number of rows: %v", err)
+	}
+
+	singular := mustRows(t, [][]float64{{1, 2}, {2, 4}})
+	if det, err := singular.Det(); err != nil || det != 0 {
+		t.Errorf("singular Det = %v, %v", det, err)
+	}
+	if _, err := singular.Inverse(); !errors.Is(err, ErrSingular) {
+		t.Errorf("singular Inverse: %v", err)
+	}
+
+	// Singular, but elimination leaves a pivot of rounding error instead of zero
+	rounded := mustRows(t, [][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}})
+	if det, err := rounded.Det(); err != nil || det != 0 {
+		t.Errorf("Det of a singular matrix within rounding error = %v, %v", det, err)
+	}
+	if inv, err := rounded.Inverse(); !errors.Is(err, ErrSingular) {
+		t.Errorf("Inverse of a singular matrix within rounding error = %v, %v", inv, err)
+	}
+
+	// The tolerance is relative, tiny entries alone do not make a matrix singular
+	tiny := mustRows(t, [][]float64{{1e-20, 0}, {0, 2e-20}})
+	if inv, err := tiny.Inverse(); err != nil || !inv.Equal(mustRows(t, [][]float64{{1e20, 0}, {0, 5e19}}), 1) {
+		t.Errorf("Inverse of a regular matrix of tiny entries = %v, %v", inv, err)
+	}
+
+	if _, err := New(2, 3).Det(); !errors.Is(err, ErrShape) {
+		t.Errorf("Det of a non-square matrix: %v", err)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	g := ParseGrid("#.S\n.#\n", func(r rune) byte { return byte(r) })
+	if r, c := g.Dims(); r != 2 || c != 3 {
+		t.Fatalf("Dims = %d, %d", r, c)
+	}
+	if g.At(Point{1, 2}) != 0 {
+		t.Error("short lines should be padded with the zero value")
+	}
+
+	start, ok := g.Find(func(b byte) bool { return b == 'S' })
+	if !ok || start != (Point{0, 2}) {
+		t.Errorf("Find = %v, %v", start, ok)
+	}
+	if _, ok := g.Get(start.Add(Up)); ok || g.In(start.Add(Right)) {
+		t.Error("positions outside of the grid")
+	}
+
+	var around []Point
+	for p := range g.Neighbors(Point{0, 1}, Around) {
+		around = append(around, p)
+	}
+	if want := []Point{{0, 2}, {1, 2}, {1, 1}, {1, 0}, {0, 0}}; !slices.Equal(around, want) {
+		t.Errorf("Neighbors = %v, want %v", around, want)
+	}
+
+	c := g.Clone()
+	c.Set(Point{0, 0}, '.')
+	count := 0
+	for _, v := range g.All() {
+		if v == '#' {
+			count++
+		}
+	}
+	if count != 2 {
+		t.Errorf("the clone shares the cells of the grid, %d walls left", count)
+	}
+
+	format := g.Format(func(b byte) string {
+		if b == 0 {
+			return " "
+		}
+		return string(b)
+	})
+	if format != "#.S\n.# \n" {
+		t.Errorf("Format = %q", format)
+	}
+}
+
+func BenchmarkMul(b *testing.B) {
+	rng := rand.New(rand.NewPCG(5, 6))
+	for _, n := range []int{64, 256, 512} {
+		x, y := random(rng, n, n), random(rng, n, n)
+		for _, variant := range []struct {
+			name string
+			mul  func(*Matrix, *Matrix) (*Matrix, error)
+		}{
+			{"naive", (*Matrix).Mul},
+			{"blocked", (*Matrix).MulBlocked},
+			{"parallel", (*Matrix).MulParallel},
+		} {
+			b.Run(fmt.Sprintf("n=%d/%s", n, variant.name), func(b *testing.B) {
+				for range b.N {
+					variant.mul(x, y)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package matrix
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// blockSize is the edge of the square tiles used by MulBlocked,
+// three 64x64 tiles of float64 fit in a typical 128 KiB L2 cache
+const blockSize = 64
+
+// Mul returns the product "m × n" computed with the textbook triple loop,
+// in "i, k, j" order so that the innermost loop walks both matrices row-wise
+func (m *Matrix) Mul(n *Matrix) (*Matrix, error) {
+	if m.cols != n.rows {
+		return nil, fmt.Errorf("%w: cannot multiply %dx%d by %dx%d", ErrShape, m.rows, m.cols, n.rows, n.cols)
+	}
+
+	out := New(m.rows, n.cols)
+	mulRows(out, m, n, 0, m.rows)
+	return out, nil
+}
+
+func mulRows(out, m, n *Matrix, from, to int) {
+	for i := from; i < to; i++ {
+		row := out.data[i*n.cols : (i+1)*n.cols]
+		for k := 0; k < m.cols; k++ {
+			a := m.data[i*m.cols+k]
+			if a == 0 {
+				continue
+			}
+			for j, b := range n.data[k*n.cols : (k+1)*n.cols] {
+				row[j] += a * b
+			}
+		}
+	}
+}
+
+// MulBlocked returns the product "m × n", working on square tiles
+// so that the parts of both operands in use stay in the cache
+func (m *Matrix) MulBlocked(n *Matrix) (*Matrix, error) {
+	if m.cols != n.rows {
+		return nil, fmt.Errorf("%w: cannot multiply %dx%d by %dx%d", ErrShape, m.rows, m.cols, n.rows, n.cols)
+	}
+
+	out := New(m.rows, n.cols)
+	mulBlocked(out, m, n, 0, m.rows)
+	return out, nil
+}
+
+func mulBlocked(out, m, n *Matrix, from, to int) {
+	for ii := from; ii < to; ii += blockSize {
+		iEnd := min(ii+blockSize, to)
+		for kk := 0; kk < m.cols; kk += blockSize {
+			kEnd := min(kk+blockSize, m.cols)
+			for jj := 0; jj < n.cols; jj += blockSize {
+				jEnd := min(jj+blockSize, n.cols)
+
+				for i := ii; i < iEnd; i++ {
+					row := out.data[i*n.cols+jj : i*n.cols+jEnd]
+					for k := kk; k < kEnd; k++ {
+						a := m.data[i*m.cols+k]
+						for j, b := range n.data[k*n.cols+jj : k*n.cols+jEnd] {
+							row[j] += a * b
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// MulParallel returns the product "m × n", splitting the rows of the result
+// into bands multiplied with MulBlocked on separate goroutines, one per CPU
+func (m *Matrix) MulParallel(n *Matrix) (*Matrix, error) {
+	if m.cols != n.rows {
+		return nil, fmt.Errorf("%w: cannot multiply %dx%d by %dx%d", ErrShape, m.rows, m.cols, n.rows, n.cols)
+	}
+
+	out := New(m.rows, n.cols)
+	workers := min(runtime.GOMAXPROCS(0), (m.rows+blockSize-1)/blockSize)
+	if workers < 2 {
+		mulBlocked(out, m, n, 0, m.rows)
+		return out, nil
+	}
+
+	// Each goroutine owns a band of rows of the result, so no locking is needed
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		from, to := w*m.rows/workers, (w+1)*m.rows/workers
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mulBlocked(out, m, n, from, to)
+		}()
+	}
+	wg.Wait()
+	return out, nil
+}
@@ -0,0 +1,147 @@
+// Package ordered provides a map that remembers the order in which keys were inserted,
+// and helpers to iterate over built-in maps in a deterministic order.
+package ordered
+
+import (
+	"iter"
+)
+
+// Map is a map that iterates in insertion order.
+// Setting an existing key updates its value but keeps its position.
+// The zero value is an empty map ready to use. A Map is not safe for concurrent use
+type Map[K comparable, V any] struct {
+	items map[K]*entry[K, V]
+
+	// A circular doubly linked list through a sentinel, oldest entry first
+	root entry[K, V]
+}
+
+type entry[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *entry[K, V]
+
+	// A deleted entry keeps its links, so that an iterator standing on it,
+	// or about to step on it, can still find its way back into the list
+	deleted bool
+}
+
+// New returns an empty map
+func New[K comparable, V any]() *Map[K, V] {
+	return new(Map[K, V])
+}
+
+func (m *Map[K, V]) lazyInit() {
+	if m.items == nil {
+		m.items = make(map[K]*entry[K, V])
+		m.root.prev, m.root.next = &m.root, &m.root
+	}
+}
+
+// Set stores "value" for "key", appending the key if it is new
+func (m *Map[K, V]) Set(key K, value V) {
+	m.lazyInit()
+	if e, ok := m.items[key]; ok {
+		e.value = value
+		return
+	}
+
+	e := &entry[K, V]{key: key, value: value, prev: m.root.prev, next: &m.root}
+	m.root.prev.next = e
+	m.root.prev = e
+	m.items[key] = e
+}
+
+// Get returns the value stored for "key" and whether it was found
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	if e, ok := m.items[key]; ok {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Has reports whether "key" is in the map
+func (m *Map[K, V]) Has(key K) bool {
+	_, ok := m.items[key]
+	return ok
+}
+
+// Delete removes "key", reporting whether it was present
+func (m *Map[K, V]) Delete(key K) bool {
+	e, ok := m.items[key]
+	if !ok {
+		return false
+	}
+
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.deleted = true
+	delete(m.items, key)
+	return true
+}
+
+// Len returns the number of entries
+func (m *Map[K, V]) Len() int {
+	return len(m.items)
+}
+
+// Keys returns the keys in insertion order
+func (m *Map[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Len())
+	for k := range m.All() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Values returns the values in insertion order of their keys
+func (m *Map[K, V]) Values() []V {
+	values := make([]V, 0, m.Len())
+	for _, v := range m.All() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// All iterates over the key/value pairs in insertion order.
+// Any entry may be deleted, and new ones set, while iterating:
+// deleted entries are not visited, new ones are visited at the end
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.items == nil {
+			return
+		}
+		for e := m.root.next; e != &m.root; {
+			if !yield(e.key, e.value) {
+				return
+			}
+
+			// Step over the entries deleted by the loop body, including the current one
+			e = e.next
+			for e.deleted {
+				e = e.next
+			}
+		}
+	}
+}
+
+// Backward iterates over the key/value pairs from the most recently inserted.
+// Any entry may be deleted while iterating, deleted entries are not visited
+func (m *Map[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.items == nil {
+			return
+		}
+		for e := m.root.prev; e != &m.root; {
+			if !yield(e.key, e.value) {
+				return
+			}
+
+			e = e.prev
+			for e.deleted {
+				e = e.prev
+			}
+		}
+	}
+}
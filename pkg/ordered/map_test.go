@@ -0,0 +1,169 @@
+package ordered
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func collect[K comparable, V any](seq func(func(K, V) bool)) []K {
+	var keys []K
+	for k := range seq {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func fromKeys(keys ...int) *Map[int, string] {
+	m := New[int, string]()
+	for _, k := range keys {
+		m.Set(k, fmt.Sprint(k))
+	}
+	return m
+}
+
+func TestMapOrder(t *testing.T) {
+	m := fromKeys(3, 1, 2)
+	m.Set(1, "one")
+
+	if got := m.Keys(); !slices.Equal(got, []int{3, 1, 2}) {
+		t.Errorf("Keys() = %v", got)
+	}
+	if got := m.Values(); !slices.Equal(got, []string{"3", "one", "2"}) {
+		t.Errorf("Values() = %v", got)
+	}
+	if got := collect(m.Backward()); !slices.Equal(got, []int{2, 1, 3}) {
+		t.Errorf("Backward() = %v", got)
+	}
+	if v, ok := m.Get(1); !ok || v != "one" || !m.Has(3) || m.Has(4) {
+		t.Errorf("Get(1) = %q, %v", v, ok)
+	}
+
+	if !m.Delete(3) || m.Delete(3) || m.Len() != 2 {
+		t.Error("Delete(3) twice")
+	}
+	m.Set(3, "three")
+	if got := m.Keys(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("a deleted key set again goes to the end: %v", got)
+	}
+}
+
+func TestZeroMap(t *testing.T) {
+	var m Map[string, int]
+	if m.Len() != 0 || len(m.Keys()) != 0 || m.Delete("x") || len(collect(m.Backward())) != 0 {
+		t.Error("the zero map is not empty")
+	}
+	m.Set("a", 1)
+	if v, _ := m.Get("a"); v != 1 {
+		t.Error("Set on the zero map")
+	}
+}
+
+func TestDeleteWhileIterating(t *testing.T) {
+	tests := []struct {
+		name    string
+		visit   int   // the key whose visit deletes
+		delete  []int // the keys deleted during that visit
+		want    []int // the keys visited forward
+		reverse []int // the keys visited backward
+	}{
+		{"current", 2, []int{2}, []int{1, 2, 3, 4}, []int{4, 3, 2, 1}},
+		{"next", 1, []int{2}, []int{1, 3, 4}, []int{4, 3, 2, 1}},
+		{"current and next", 2, []int{2, 3}, []int{1, 2, 4}, []int{4, 3, 2, 1}},
+		{"next ones", 1, []int{2, 3, 4}, []int{1}, []int{4, 3, 2, 1}},
+		{"all", 2, []int{1, 2, 3, 4}, []int{1, 2}, []int{4, 3, 2}},
+		{"previous", 3, []int{2}, []int{1, 2, 3, 4}, []int{4, 3, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := fromKeys(1, 2, 3, 4)
+			var got []int
+			for k := range m.All() {
+				got = append(got, k)
+				if k == tt.visit {
+					for _, d := range tt.delete {
+						m.Delete(d)
+					}
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("All() visited %v, want %v", got, tt.want)
+			}
+
+			m = fromKeys(1, 2, 3, 4)
+			got = nil
+			for k := range m.Backward() {
+				got = append(got, k)
+				if k == tt.visit {
+					for _, d := range tt.delete {
+						m.Delete(d)
+					}
+				}
+			}
+			if !slices.Equal(got, tt.reverse) {
+				t.Errorf("Backward() visited %v, want %v", got, tt.reverse)
+			}
+		})
+	}
+}
+
+func TestDeleteNextThenSetWhileIterating(t *testing.T) {
+	m := fromKeys(1, 2, 3)
+	var got []int
+	for k := range m.All() {
+		got = append(got, k)
+		if k == 1 {
+			m.Delete(2)
+			m.Delete(3)
+			m.Set(5, "5")
+		}
+	}
+	if !slices.Equal(got, []int{1, 5}) {
+		t.Errorf("visited %v", got)
+	}
+}
+
+func TestMapJSON(t *testing.T) {
+	m := fromKeys(10, 2, 33)
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"10":"10","2":"2","33":"33"}` {
+		t.Errorf("Marshal = %s", data)
+	}
+
+	var back Map[int, string]
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if got := back.Keys(); !slices.Equal(got, []int{10, 2, 33}) {
+		t.Errorf("Unmarshal kept %v", got)
+	}
+
+	if err := json.Unmarshal([]byte(`{"x":"1"}`), &back); err == nil {
+		t.Error("a key that is not an int should fail")
+	}
+	if err := json.Unmarshal([]byte(`[]`), &back); err == nil {
+		t.Error("an array should fail")
+	}
+}
+
+func TestSorted(t *testing.T) {
+	m := map[string]int{"b": 2, "c": 3, "a": 1}
+	if got := SortedKeys(m); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("SortedKeys = %v", got)
+	}
+
+	var got []string
+	for k, v := range Sorted(m) {
+		got = append(got, fmt.Sprint(k, v))
+		if k == "b" {
+			break
+		}
+	}
+	if !slices.Equal(got, []string{"a1", "b2"}) {
+		t.Errorf("Sorted = %v", got)
+	}
+}
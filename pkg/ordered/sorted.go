@@ -0,0 +1,25 @@
+package ordered
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// SortedKeys returns the keys of "m" in ascending order
+func SortedKeys[M ~map[K]V, K cmp.Ordered, V any](m M) []K {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// Sorted iterates over the key/value pairs of "m" in ascending order of keys,
+// unlike ranging over the map itself whose order changes from run to run
+func Sorted[M ~map[K]V, K cmp.Ordered, V any](m M) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, k := range SortedKeys(m) {
+			if !yield(k, m[k]) {
+				return
+			}
+		}
+	}
+}
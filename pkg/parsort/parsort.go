@@ -0,0 +1,180 @@
+// Package parsort sorts large slices on all cores with a parallel merge sort.
+//
+// The slice is cut into one chunk per worker, the chunks are sorted concurrently,
+// then merged pairwise. Each merge is itself split across the idle workers
+// by binary searching for matching cut points, so the final merge of the whole slice
+// does not run on a single core.
+package parsort
+
+import (
+	"cmp"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// MinChunk is the smallest number of elements worth handing to a separate goroutine,
+// slices shorter than two chunks are sorted sequentially
+const MinChunk = 1 << 14
+
+// Sort sorts "s" in ascending order using every available core
+func Sort[T cmp.Ordered](s []T) {
+	workers := runtime.GOMAXPROCS(0)
+
+	// The specialized sequential sort is faster than going through a comparison function
+	if workers < 2 || len(s) < 2*MinChunk {
+		slices.Sort(s)
+		return
+	}
+	SortFuncN(s, cmp.Compare[T], workers)
+}
+
+// SortFunc sorts "s" in the order defined by "cmp" using every available core,
+// equal elements may be reordered
+func SortFunc[T any](s []T, cmp func(a, b T) int) {
+	SortFuncN(s, cmp, runtime.GOMAXPROCS(0))
+}
+
+// SortStableFunc is like SortFunc but keeps the original order of equal elements
+func SortStableFunc[T any](s []T, cmp func(a, b T) int) {
+	SortStableFuncN(s, cmp, runtime.GOMAXPROCS(0))
+}
+
+// SortFuncN sorts "s" with at most "workers" goroutines running at a time
+func SortFuncN[T any](s []T, cmp func(a, b T) int, workers int) {
+	sortFunc(s, cmp, workers, false)
+}
+
+// SortStableFuncN sorts "s" stably with at most "workers" goroutines running at a time
+func SortStableFuncN[T any](s []T, cmp func(a, b T) int, workers int) {
+	sortFunc(s, cmp, workers, true)
+}
+
+func sortFunc[T any](s []T, cmp func(a, b T) int, workers int, stable bool) {
+	chunks := min(max(workers, 1), len(s)/MinChunk)
+	if chunks < 2 {
+		if stable {
+			slices.SortStableFunc(s, cmp)
+		} else {
+			slices.SortFunc(s, cmp)
+		}
+		return
+	}
+
+	// Sort every chunk on its own goroutine, there are never more chunks than workers
+	bounds := make([]int, chunks+1)
+	for i := range bounds {
+		bounds[i] = i * len(s) / chunks
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < chunks; i++ {
+		chunk := s[bounds[i]:bounds[i+1]]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if stable {
+				slices.SortStableFunc(chunk, cmp)
+			} else {
+				slices.SortFunc(chunk, cmp)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Merge neighbouring chunks back and forth between "s" and a buffer
+	// until a single run is left, halving the number of runs every round
+	src, dst := s, make([]T, len(s))
+	for len(bounds) > 2 {
+		pairs := (len(bounds) - 1) / 2
+		parts := max(workers/pairs, 1)
+
+		next := []int{0}
+		for i := 0; i+1 < len(bounds); i += 2 {
+			lo := bounds[i]
+			if i+2 >= len(bounds) {
+				// An odd run out has nothing to merge with
+				hi := bounds[i+1]
+				copy(dst[lo:hi], src[lo:hi])
+				next = append(next, hi)
+				continue
+			}
+
+			mid, hi := bounds[i+1], bounds[i+2]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				parallelMerge(dst[lo:hi], src[lo:mid], src[mid:hi], cmp, parts)
+			}()
+			next = append(next, hi)
+		}
+		wg.Wait()
+
+		src, dst = dst, src
+		bounds = next
+	}
+
+	// After an odd number of rounds the result lives in the buffer
+	if &src[0] != &s[0] {
+		copy(s, src)
+	}
+}
+
+// parallelMerge merges the sorted "a" and "b" into "dst" using up to "parts" goroutines.
+// Elements of "a" come before equal elements of "b", so the merge is stable
+func parallelMerge[T any](dst, a, b []T, cmp func(a, b T) int, parts int) {
+	if parts < 2 || len(a)+len(b) < 2*MinChunk {
+		merge(dst, a, b, cmp)
+		return
+	}
+
+	// Cut the longer input in half and find where its middle element lands in the other
+	var i, j int
+	if len(a) >= len(b) {
+		i = len(a) / 2
+		j, _ = slices.BinarySearchFunc(b, a[i], cmp)
+	} else {
+		j = len(b) / 2
+		i = upperBound(a, b[j], cmp)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		parallelMerge(dst[:i+j], a[:i], b[:j], cmp, parts/2)
+	}()
+	parallelMerge(dst[i+j:], a[i:], b[j:], cmp, parts-parts/2)
+	wg.Wait()
+}
+
+// merge merges the sorted "a" and "b" into "dst" sequentially
+func merge[T any](dst, a, b []T, cmp func(a, b T) int) {
+	i, j, k := 0, 0, 0
+	for i < len(a) && j < len(b) {
+		if cmp(b[j], a[i]) < 0 {
+			dst[k] = b[j]
+			j++
+		} else {
+			dst[k] = a[i]
+			i++
+		}
+		k++
+	}
+	k += copy(dst[k:], a[i:])
+	copy(dst[k:], b[j:])
+}
+
+// upperBound returns the index of the first element of "s" greater than "v"
+func upperBound[T any](s []T, v T, cmp func(a, b T) int) int {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		if cmp(s[m], v) <= 0 {
+			lo = m + 1
+		} else {
+			hi = m
+		}
+	}
+	return lo
+}
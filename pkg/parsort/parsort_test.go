@@ -0,0 +1,147 @@
+package parsort
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// The sizes around the sequential cutoff, and a few chunks more
+var sizes = []int{0, 1, 2, 100, MinChunk - 1, 2*MinChunk - 1, 2 * MinChunk, 5*MinChunk + 3}
+
+func randomInts(n int, seed uint64) []int {
+	r := rand.New(rand.NewPCG(seed, 0))
+	s := make([]int, n)
+	for i := range s {
+		s[i] = r.IntN(n/4 + 1)
+	}
+	return s
+}
+
+func TestSort(t *testing.T) {
+	for _, n := range sizes {
+		s := randomInts(n, uint64(n))
+		want := slices.Clone(s)
+		slices.Sort(want)
+
+		Sort(s)
+		if !slices.Equal(s, want) {
+			t.Errorf("n=%d: not sorted", n)
+		}
+	}
+}
+
+func TestSortFuncN(t *testing.T) {
+	desc := func(a, b int) int { return cmp.Compare(b, a) }
+	for _, n := range sizes {
+		for _, workers := range []int{0, 1, 2, 3, 8} {
+			s := randomInts(n, uint64(n+workers))
+			SortFuncN(s, desc, workers)
+			if !slices.IsSortedFunc(s, desc) {
+				t.Errorf("n=%d workers=%d: not sorted", n, workers)
+			}
+		}
+	}
+}
+
+type item struct {
+	key, index int
+}
+
+func TestSortStableFuncN(t *testing.T) {
+	byKey := func(a, b item) int { return cmp.Compare(a.key, b.key) }
+	for _, n := range sizes {
+		for _, workers := range []int{1, 2, 3, 8} {
+			keys := randomInts(n, uint64(n*workers))
+			s := make([]item, n)
+			for i, k := range keys {
+				s[i] = item{k % 16, i}
+			}
+			want := slices.Clone(s)
+			slices.SortStableFunc(want, byKey)
+
+			SortStableFuncN(s, byKey, workers)
+			if !slices.Equal(s, want) {
+				t.Errorf("n=%d workers=%d: equal keys lost their order", n, workers)
+			}
+		}
+	}
+}
+
+func TestTopK(t *testing.T) {
+	s := randomInts(1000, 7)
+	want := slices.Clone(s)
+	slices.Sort(want)
+	slices.Reverse(want)
+
+	for _, k := range []int{0, 1, 10, 1000, 2000} {
+		got := TopK(s, k)
+		if !slices.Equal(got, want[:min(k, len(want))]) {
+			t.Errorf("k=%d: got %v", k, got)
+		}
+	}
+
+	ch := make(chan int)
+	go func() {
+		for _, v := range s {
+			ch <- v
+		}
+		close(ch)
+	}()
+	if got := TopKChan(ch, 5, cmp.Compare[int]); !slices.Equal(got, want[:5]) {
+		t.Errorf("TopKChan: got %v, want %v", got, want[:5])
+	}
+}
+
+func TestTopMin(t *testing.T) {
+	top := NewTop(2, cmp.Compare[int])
+	if _, ok := top.Min(); ok {
+		t.Error("Min of an empty Top")
+	}
+	for _, v := range []int{5, 1, 9, 3} {
+		top.Push(v)
+	}
+	if v, _ := top.Min(); v != 5 || top.Len() != 2 {
+		t.Errorf("Min = %d, Len = %d", v, top.Len())
+	}
+}
+
+// BenchmarkSort compares Sort with "sort.Slice" for 1M to 100M elements,
+// with GOMAXPROCS at 1, 4 and the number of CPUs. The largest size is skipped with -short
+func BenchmarkSort(b *testing.B) {
+	procs := []int{1, 4, runtime.NumCPU()}
+	slices.Sort(procs)
+	procs = slices.Compact(procs)
+	for _, n := range []int{1_000_000, 10_000_000, 100_000_000} {
+		if n > 10_000_000 && testing.Short() {
+			continue
+		}
+		input := randomInts(n, 1)
+		s := make([]int, n)
+
+		b.Run(fmt.Sprintf("n=%d/sort.Slice", n), func(b *testing.B) {
+			for range b.N {
+				b.StopTimer()
+				copy(s, input)
+				b.StartTimer()
+				sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+			}
+		})
+
+		for _, p := range procs {
+			b.Run(fmt.Sprintf("n=%d/procs=%d", n, p), func(b *testing.B) {
+				defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(p))
+				for range b.N {
+					b.StopTimer()
+					copy(s, input)
+					b.StartTimer()
+					Sort(s)
+				}
+			})
+		}
+	}
+}
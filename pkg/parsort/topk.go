@@ -0,0 +1,109 @@
+package parsort
+
+import (
+	"cmp"
+	"slices"
+)
+
+// TopK returns the "k" greatest elements of "s" in descending order,
+// without sorting or modifying "s"
+func TopK[T cmp.Ordered](s []T, k int) []T {
+	return TopKFunc(s, k, cmp.Compare[T])
+}
+
+// TopKFunc returns the "k" greatest elements of "s" according to "cmp" in descending order
+func TopKFunc[T any](s []T, k int, cmp func(a, b T) int) []T {
+	top := NewTop(k, cmp)
+	for _, v := range s {
+		top.Push(v)
+	}
+	return top.Result()
+}
+
+// TopKChan consumes "ch" until it is closed
+// and returns the "k" greatest elements received in descending order
+func TopKChan[T any](ch <-chan T, k int, cmp func(a, b T) int) []T {
+	top := NewTop(k, cmp)
+	for v := range ch {
+		top.Push(v)
+	}
+	return top.Result()
+}
+
+// Top keeps track of the "k" greatest elements pushed so far in O(k) memory.
+// It is a min-heap whose root is the smallest element still kept,
+// so every push costs at most O(log k). A Top is not safe for concurrent use
+type Top[T any] struct {
+	k    int
+	cmp  func(a, b T) int
+	heap []T
+}
+
+// NewTop returns a Top that keeps the "k" greatest elements according to "cmp"
+func NewTop[T any](k int, cmp func(a, b T) int) *Top[T] {
+	return &Top[T]{k: max(k, 0), cmp: cmp, heap: make([]T, 0, max(k, 0))}
+}
+
+// Push offers "v", keeping it only if it is among the "k" greatest elements seen
+func (t *Top[T]) Push(v T) {
+	switch {
+	case t.k == 0:
+	case len(t.heap) < t.k:
+		t.heap = append(t.heap, v)
+		t.up(len(t.heap) - 1)
+	case t.cmp(v, t.heap[0]) > 0:
+		t.heap[0] = v
+		t.down(0)
+	}
+}
+
+// Len returns the number of elements currently kept
+func (t *Top[T]) Len() int {
+	return len(t.heap)
+}
+
+// Min returns the smallest element kept, the one the next greater push will evict
+func (t *Top[T]) Min() (v T, ok bool) {
+	if len(t.heap) == 0 {
+		return v, false
+	}
+	return t.heap[0], true
+}
+
+// Result returns a copy of the elements kept in descending order
+func (t *Top[T]) Result() []T {
+	result := slices.Clone(t.heap)
+	slices.SortFunc(result, func(a, b T) int {
+		return t.cmp(b, a)
+	})
+	return result
+}
+
+func (t *Top[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if t.cmp(t.heap[i], t.heap[parent]) >= 0 {
+			return
+		}
+		t.heap[i], t.heap[parent] = t.heap[parent], t.heap[i]
+		i = parent
+	}
+}
+
+func (t *Top[T]) down(i int) {
+	n := len(t.heap)
+	for {
+		smallest := i
+		if l := 2*i + 1; l < n && t.cmp(t.heap[l], t.heap[smallest]) < 0 {
+			smallest = l
+		}
+		if r := 2*i + 2; r < n && t.cmp(t.heap[r], t.heap[smallest]) < 0 {
+			smallest = r
+		}
+		if smallest == i {
+			return
+		}
+		t.heap[i], t.heap[smallest] = t.heap[smallest], t.heap[i]
+		i = smallest
+	}
+}
@@ -0,0 +1,214 @@
+package person
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hieuvp/learning-golang/pkg/errs"
+)
+
+// RowError reports a problem with a single record of an imported file
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap returns the underlying problem, e.g. the validation errors of the record
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// LoadFile loads people from a ".csv" or ".json" file, see LoadCSV and LoadJSON
+func LoadFile(path string) ([]Person, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".csv":
+		return LoadCSV(f)
+	case ".json":
+		return LoadJSON(f)
+	default:
+		return nil, fmt.Errorf("person: unsupported file extension %q", ext)
+	}
+}
+
+// LoadCSV loads people from CSV with a header row.
+// The "name" column is required, "age" and "email" are optional, other columns are ignored.
+//
+// Every valid record is returned. Records that cannot be parsed or are not valid
+// are skipped and reported together as an *errs.MultiError of *RowError,
+// any other error stops the import
+func LoadCSV(r io.Reader) ([]Person, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errs.New(errs.InvalidArgument, `missing "name" column`, "line", 1)
+	}
+
+	var (
+		people []Person
+		list   errs.List
+	)
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var perr *csv.ParseError
+		if errors.As(err, &perr) {
+			list.Add(&RowError{Line: perr.StartLine, Err: perr.Err})
+			continue
+		}
+		if err != nil {
+			return people, err
+		}
+
+		line, _ := cr.FieldPos(0)
+		p, err := fromRecord(record, columns)
+		if err == nil {
+			err = p.Validate()
+		}
+		if err != nil {
+			list.Add(&RowError{Line: line, Err: err})
+			continue
+		}
+		people = append(people, p)
+	}
+
+	return people, list.Err()
+}
+
+// fromRecord maps the columns of a CSV record to a Person
+func fromRecord(record []string, columns map[string]int) (Person, error) {
+	get := func(column string) string {
+		if i, ok := columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	p := Person{Name: get("name"), Email: get("email")}
+	if age := get("age"); age != "" {
+		n, err := strconv.Atoi(age)
+		if err != nil {
+			return p, errs.New(errs.InvalidArgument, "age is not a number", "field", "age", "value", age)
+		}
+		p.Age = n
+	}
+	return p, nil
+}
+
+// LoadJSON loads people from a JSON array of objects with "name", "age" and "email" fields.
+// Errors are reported like in LoadCSV, with the line where each object starts
+func LoadJSON(r io.Reader) ([]Person, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, syntaxError(data, err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, errs.New(errs.InvalidArgument, "expected a JSON array of people", "line", 1)
+	}
+
+	var (
+		people []Person
+		list   errs.List
+	)
+	for dec.More() {
+		line := lineAt(data, skipSeparators(data, int(dec.InputOffset())))
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return people, syntaxError(data, err)
+		}
+
+		var p Person
+		err := decodeStrict(raw, &p)
+		if err == nil {
+			err = p.Validate()
+		}
+		if err != nil {
+			list.Add(&RowError{Line: line, Err: err})
+			continue
+		}
+		people = append(people, p)
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return people, syntaxError(data, err)
+	}
+	return people, list.Err()
+}
+
+// decodeStrict decodes a single object, rejecting fields a Person does not have
+func decodeStrict(raw []byte, p *Person) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(p); err != nil {
+		return errs.Wrap(err, errs.InvalidArgument, "invalid person")
+	}
+	return nil
+}
+
+// syntaxError adds the line number to a JSON syntax error, which only knows its offset
+func syntaxError(data []byte, err error) error {
+	var serr *json.SyntaxError
+	if errors.As(err, &serr) {
+		return &RowError{Line: lineAt(data, int(serr.Offset)), Err: err}
+	}
+	return err
+}
+
+// skipSeparators returns the offset of the first byte at or after "offset"
+// that is neither white space nor a comma, where the next value starts
+func skipSeparators(data []byte, offset int) int {
+	for offset < len(data) && strings.IndexByte(" \t\r\n,", data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+// lineAt returns the 1-based line number of the byte at "offset"
+func lineAt(data []byte, offset int) int {
+	offset = min(max(offset, 0), len(data))
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
@@ -0,0 +1,75 @@
+// Package person is the domain model behind the "Person" struct of the structs example,
+// with exported fields, validation, and loaders for CSV and JSON files.
+package person
+
+import (
+	"net/mail"
+	"strings"
+
+	"github.com/hieuvp/learning-golang/pkg/errs"
+)
+
+// The range of accepted ages, inclusive
+const (
+	MinAge = 0
+	MaxAge = 150
+)
+
+// Person is a named person with an age and an optional email address
+type Person struct {
+	Name  string `json:"name"`
+	Age   int    `json:"age"`
+	Email string `json:"email,omitempty"`
+}
+
+// Option configures a Person built by New
+type Option func(*Person)
+
+// WithAge sets the age of the person
+func WithAge(age int) Option {
+	return func(p *Person) {
+		p.Age = age
+	}
+}
+
+// WithEmail sets the email address of the person
+func WithEmail(email string) Option {
+	return func(p *Person) {
+		p.Email = email
+	}
+}
+
+// New constructs a Person with the given name and options,
+// and returns an error when the result is not valid
+func New(name string, opts ...Option) (*Person, error) {
+	p := &Person{Name: name}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Validate checks every field and reports all the problems at once.
+// Each problem is an *errs.Error with the InvalidArgument code and a "field" field
+func (p Person) Validate() error {
+	var list errs.List
+
+	if strings.TrimSpace(p.Name) == "" {
+		list.Add(errs.New(errs.InvalidArgument, "name must not be empty", "field", "name"))
+	}
+	if p.Age < MinAge || p.Age > MaxAge {
+		list.Add(errs.Errorf(errs.InvalidArgument, "age must be between %d and %d, got %d", MinAge, MaxAge, p.Age).
+			With("field", "age"))
+	}
+	if p.Email != "" {
+		if _, err := mail.ParseAddress(p.Email); err != nil {
+			list.Add(errs.New(errs.InvalidArgument, "invalid email address", "field", "email", "email", p.Email))
+		}
+	}
+
+	return list.Err()
+}
@@ -0,0 +1,164 @@
+package person
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hieuvp/learning-golang/pkg/errs"
+)
+
+// problems returns the fields reported by a validation error, in order
+func problems(err error) []string {
+	var fields []string
+	var visit func(error)
+	visit = func(err error) {
+		if m, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range m.Unwrap() {
+				visit(e)
+			}
+			return
+		}
+		var e *errs.Error
+		if errors.As(err, &e) {
+			if f, ok := e.Field("field"); ok {
+				fields = append(fields, f.(string))
+			}
+		}
+	}
+	visit(err)
+	return fields
+}
+
+func TestNew(t *testing.T) {
+	p, err := New("Alice", WithAge(30), WithEmail("alice@example.com"))
+	if err != nil || *p != (Person{"Alice", 30, "alice@example.com"}) {
+		t.Errorf("New = %+v, %v", p, err)
+	}
+
+	tests := []struct {
+		name   string
+		opts   []Option
+		fields []string
+	}{
+		{"Bob", nil, nil},
+		{"Bob", []Option{WithAge(MaxAge)}, nil},
+		{" ", nil, []string{"name"}},
+		{"Bob", []Option{WithAge(-1)}, []string{"age"}},
+		{"Bob", []Option{WithAge(MaxAge + 1)}, []string{"age"}},
+		{"Bob", []Option{WithEmail("not an address")}, []string{"email"}},
+		{"", []Option{WithAge(200), WithEmail("@")}, []string{"name", "age", "email"}},
+	}
+	for _, tt := range tests {
+		_, err := New(tt.name, tt.opts...)
+		if got := problems(err); !slices.Equal(got, tt.fields) {
+			t.Errorf("New(%q) problems %v, want %v (%v)", tt.name, got, tt.fields, err)
+		}
+		if err != nil && errs.CodeOf(err) != errs.InvalidArgument {
+			t.Errorf("New(%q) code %v", tt.name, errs.CodeOf(err))
+		}
+	}
+}
+
+// rowLines returns the lines of the rows reported by an import error
+func rowLines(t *testing.T, err error) []int {
+	t.Helper()
+	m, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("%v is not a list of errors", err)
+	}
+	var lines []int
+	for _, e := range m.Unwrap() {
+		var row *RowError
+		if !errors.As(e, &row) {
+			t.Fatalf("%v is not a *RowError", e)
+		}
+		lines = append(lines, row.Line)
+	}
+	return lines
+}
+
+func TestLoadCSV(t *testing.T) {
+	input := `Email, NAME, age, notes
+alice@example.com, Alice, 30, ignored
+, Bob,,
+carol@example.com, Carol, old
+, , 20
+"unterminated, Dan, 40
+`
+	people, err := LoadCSV(strings.NewReader(input))
+	want := []Person{{"Alice", 30, "alice@example.com"}, {Name: "Bob"}}
+	if !slices.Equal(people, want) {
+		t.Errorf("people = %v, want %v", people, want)
+	}
+	if lines := rowLines(t, err); !slices.Equal(lines, []int{4, 5, 6}) {
+		t.Errorf("errors on lines %v: %v", lines, err)
+	}
+
+	if people, err := LoadCSV(strings.NewReader("")); people != nil || err != nil {
+		t.Errorf("empty input: %v, %v", people, err)
+	}
+	if _, err := LoadCSV(strings.NewReader("age,email\n1,a@b.c\n")); errs.CodeOf(err) != errs.InvalidArgument {
+		t.Errorf("missing name column: %v", err)
+	}
+}
+
+func TestLoadJSON(t *testing.T) {
+	input := `[
+  {"name": "Alice", "age": 30, "email": "alice@example.com"},
+  {"name": "Bob"},
+  {"name": "Carol", "age": "old"},
+  {"name": "Dan", "nickname": "D"},
+  {"name": "", "age": 999}
+]`
+	people, err := LoadJSON(strings.NewReader(input))
+	want := []Person{{"Alice", 30, "alice@example.com"}, {Name: "Bob"}}
+	if !slices.Equal(people, want) {
+		t.Errorf("people = %v, want %v", people, want)
+	}
+	if lines := rowLines(t, err); !slices.Equal(lines, []int{4, 5, 6}) {
+		t.Errorf("errors on lines %v: %v", lines, err)
+	}
+
+	var row *RowError
+	_, err = LoadJSON(strings.NewReader("[\n{\"name\": \"Alice\"},\n{\"name\": }\n]"))
+	if !errors.As(err, &row) || row.Line != 3 {
+		t.Errorf("syntax error: %v", err)
+	}
+	if _, err := LoadJSON(strings.NewReader(`{"name": "Alice"}`)); errs.CodeOf(err) != errs.InvalidArgument {
+		t.Errorf("not an array: %v", err)
+	}
+	if people, err := LoadJSON(strings.NewReader("")); people != nil || err != nil {
+		t.Errorf("empty input: %v, %v", people, err)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"people.csv":  "name,age\nAlice,30\n",
+		"people.JSON": `[{"name": "Alice", "age": 30}]`,
+		"people.txt":  "Alice",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, name := range []string{"people.csv", "people.JSON"} {
+		people, err := LoadFile(filepath.Join(dir, name))
+		if err != nil || !slices.Equal(people, []Person{{Name: "Alice", Age: 30}}) {
+			t.Errorf("LoadFile(%s) = %v, %v", name, people, err)
+		}
+	}
+	if _, err := LoadFile(filepath.Join(dir, "people.txt")); err == nil {
+		t.Error("an unknown extension should fail")
+	}
+	if _, err := LoadFile(filepath.Join(dir, "missing.csv")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing file: %v", err)
+	}
+}
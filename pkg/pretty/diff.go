@@ -0,0 +1,192 @@
+package pretty
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Missing stands for the side of a difference where a map key or a slice element does not exist
+const Missing = "<missing>"
+
+// Difference is a place where two values differ, with both sides rendered on a single line
+type Difference struct {
+	// Path leads from the compared values to the difference, e.g. `.Items[2].Tags["env"]`,
+	// it is empty when the values themselves differ
+	Path string
+	A, B string
+}
+
+func (d Difference) String() string {
+	if d.Path == "" {
+		return d.A + " != " + d.B
+	}
+	return d.Path + ": " + d.A + " != " + d.B
+}
+
+// Diffs is the list of differences returned by Diff
+type Diffs []Difference
+
+// String lists the differences, one per line
+func (ds Diffs) String() string {
+	lines := make([]string, len(ds))
+	for i, d := range ds {
+		lines[i] = d.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Diff compares "a" and "b" deeply, like "reflect.DeepEqual", and returns every difference,
+// or nil when they are equal. Map keys are visited in sorted order so the result is stable.
+// Pointers are followed, the path does not show them
+func Diff(a, b any) Diffs {
+	d := &differ{seen: map[seenPair]bool{}, printer: Printer{Compact: true}}
+	d.diff("", reflect.ValueOf(a), reflect.ValueOf(b))
+	return d.diffs
+}
+
+type seenPair struct {
+	a, b       uintptr
+	typ        reflect.Type
+	lenA, lenB int
+}
+
+type differ struct {
+	diffs   Diffs
+	seen    map[seenPair]bool
+	printer Printer
+}
+
+func (d *differ) report(path string, a, b reflect.Value) {
+	d.diffs = append(d.diffs, Difference{path, d.show(a), d.show(b)})
+}
+
+func (d *differ) show(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return d.printer.sprintValue(v)
+}
+
+func (d *differ) diff(path string, a, b reflect.Value) {
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			d.report(path, a, b)
+		}
+		return
+	}
+	if a.Type() != b.Type() {
+		d.report(path, a, b)
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.report(path, a, b)
+			}
+			return
+		}
+		d.diff(path, a.Elem(), b.Elem())
+
+	case reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.report(path, a, b)
+			}
+			return
+		}
+		if d.enter(a, b) {
+			d.diff(path, a.Elem(), b.Elem())
+		}
+
+	case reflect.Struct:
+		for i := range a.NumField() {
+			d.diff(path+"."+a.Type().Field(i).Name, a.Field(i), b.Field(i))
+		}
+
+	case reflect.Slice, reflect.Array:
+		if a.Kind() == reflect.Slice && (a.IsNil() || b.IsNil()) {
+			if a.IsNil() != b.IsNil() {
+				d.report(path, a, b)
+			}
+			return
+		}
+		if a.Kind() == reflect.Slice && !d.enter(a, b) {
+			return
+		}
+		for i := range max(a.Len(), b.Len()) {
+			p := path + "[" + strconv.Itoa(i) + "]"
+			switch {
+			case i >= a.Len():
+				d.diffs = append(d.diffs, Difference{p, Missing, d.show(b.Index(i))})
+			case i >= b.Len():
+				d.diffs = append(d.diffs, Difference{p, d.show(a.Index(i)), Missing})
+			default:
+				d.diff(p, a.Index(i), b.Index(i))
+			}
+		}
+
+	case reflect.Map:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.report(path, a, b)
+			}
+			return
+		}
+		if !d.enter(a, b) {
+			return
+		}
+		for _, k := range unionKeys(a, b) {
+			p := path + "[" + d.show(k) + "]"
+			va, vb := a.MapIndex(k), b.MapIndex(k)
+			switch {
+			case !va.IsValid():
+				d.diffs = append(d.diffs, Difference{p, Missing, d.show(vb)})
+			case !vb.IsValid():
+				d.diffs = append(d.diffs, Difference{p, d.show(va), Missing})
+			default:
+				d.diff(p, va, vb)
+			}
+		}
+
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		// Like "reflect.DeepEqual", functions are only equal when both are nil
+		if a.Kind() == reflect.Func && (!a.IsNil() || !b.IsNil()) || a.Pointer() != b.Pointer() {
+			d.report(path, a, b)
+		}
+
+	default:
+		if d.show(a) != d.show(b) {
+			d.report(path, a, b)
+		}
+	}
+}
+
+// enter reports whether the pair of references has not been compared yet,
+// which stops the comparison of cyclic values. Slices that start at the same address
+// are only the same when they have the same length too
+func (d *differ) enter(a, b reflect.Value) bool {
+	key := seenPair{a: a.Pointer(), b: b.Pointer(), typ: a.Type()}
+	if a.Kind() == reflect.Slice {
+		key.lenA, key.lenB = a.Len(), b.Len()
+	}
+	if d.seen[key] {
+		return false
+	}
+	d.seen[key] = true
+	return true
+}
+
+// unionKeys returns the keys of both maps once each, sorted
+func unionKeys(a, b reflect.Value) []reflect.Value {
+	keys := sortedKeys(a)
+	for _, k := range sortedKeys(b) {
+		if !a.MapIndex(k).IsValid() {
+			keys = append(keys, k)
+		}
+	}
+	sortValues(keys)
+	return keys
+}
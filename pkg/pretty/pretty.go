@@ -0,0 +1,248 @@
+// Package pretty renders any value the way the "whatAmI" type switch of the switch example
+// would if it knew every type: nested structs, maps with sorted keys, slices and pointers,
+// as Go-like literals that are stable from one run to the next.
+// It also compares two values and reports where they differ, see Diff
+package pretty
+
+import (
+	"cmp"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Printer renders values as Go-like literals.
+// Composite values are broken into one element per line unless they are short enough
+// to fit on a single line, or the printer is Compact
+type Printer struct {
+	// Indent is repeated once per level of nesting, two spaces when empty
+	Indent string
+
+	// Compact renders everything on a single line
+	Compact bool
+}
+
+// maxInline is the longest composite value rendered on a single line
+const maxInline = 60
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Sprint renders "v" with the default printer
+func Sprint(v any) string {
+	return Printer{}.Sprint(v)
+}
+
+// Fprint writes "v" rendered with the default printer, followed by a new line
+func Fprint(w io.Writer, v any) error {
+	return Printer{}.Fprint(w, v)
+}
+
+// Sprint renders "v"
+func (p Printer) Sprint(v any) string {
+	return p.sprintValue(reflect.ValueOf(v))
+}
+
+// Fprint writes "v" rendered, followed by a new line
+func (p Printer) Fprint(w io.Writer, v any) error {
+	_, err := io.WriteString(w, p.Sprint(v)+"\n")
+	return err
+}
+
+// visit identifies a pointer, map or slice being rendered, to detect cycles
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+type state struct {
+	Printer
+	visiting map[visit]bool
+}
+
+func (s *state) render(v reflect.Value, depth int) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return named(v, strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Type() == reflect.TypeOf(time.Duration(0)) {
+			return named(v, time.Duration(v.Int()).String())
+		}
+		return named(v, strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return named(v, strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		return named(v, strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()))
+	case reflect.Complex64, reflect.Complex128:
+		return named(v, strconv.FormatComplex(v.Complex(), 'g', -1, v.Type().Bits()))
+	case reflect.String:
+		return named(v, strconv.Quote(v.String()))
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return "nil"
+		}
+		return s.render(v.Elem(), depth)
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			return "nil"
+		}
+		return s.guard(v, func() string {
+			return "&" + s.render(v.Elem(), depth)
+		})
+
+	case reflect.Struct:
+		if v.Type() == timeType && v.CanInterface() {
+			return "time.Time(" + v.Interface().(time.Time).Format(time.RFC3339Nano) + ")"
+		}
+		items := make([]string, v.NumField())
+		for i := range items {
+			items[i] = v.Type().Field(i).Name + ": " + s.render(v.Field(i), depth+1)
+		}
+		return s.block(v.Type().String()+"{", items, "}", depth)
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return "nil"
+		}
+		return s.guard(v, func() string {
+			return s.block(v.Type().String()+"{", s.elements(v, depth), "}", depth)
+		})
+
+	case reflect.Array:
+		return s.block(v.Type().String()+"{", s.elements(v, depth), "}", depth)
+
+	case reflect.Map:
+		if v.IsNil() {
+			return "nil"
+		}
+		return s.guard(v, func() string {
+			keys := sortedKeys(v)
+			items := make([]string, len(keys))
+			for i, k := range keys {
+				items[i] = s.key(k) + ": " + s.render(v.MapIndex(k), depth+1)
+			}
+			return s.block(v.Type().String()+"{", items, "}", depth)
+		})
+
+	default:
+		// Channels, functions and unsafe pointers only show their type,
+		// their address would change from one run to the next
+		if v.IsNil() {
+			return "nil"
+		}
+		return "<" + v.Type().String() + ">"
+	}
+}
+
+// named wraps the literal of a value of a defined type in a conversion, e.g. "time.Month(10)"
+func named(v reflect.Value, literal string) string {
+	if v.Type().PkgPath() == "" {
+		return literal
+	}
+	return v.Type().String() + "(" + literal + ")"
+}
+
+// guard renders a value that may be part of a cycle, or a marker if it is already being rendered
+func (s *state) guard(v reflect.Value, render func() string) string {
+	key := visit{v.Pointer(), v.Type(), 0}
+	if v.Kind() == reflect.Slice {
+		key.len = v.Len()
+	}
+	if s.visiting[key] {
+		return "<cycle " + v.Type().String() + ">"
+	}
+
+	s.visiting[key] = true
+	defer delete(s.visiting, key)
+	return render()
+}
+
+func (s *state) elements(v reflect.Value, depth int) []string {
+	items := make([]string, v.Len())
+	for i := range items {
+		items[i] = s.render(v.Index(i), depth+1)
+	}
+	return items
+}
+
+// key renders a map key, always on a single line
+func (s *state) key(k reflect.Value) string {
+	compact := *s
+	compact.Compact = true
+	return compact.render(k, 0)
+}
+
+// block lays out the items of a composite value, on a single line when they fit
+func (s *state) block(open string, items []string, closing string, depth int) string {
+	if len(items) == 0 {
+		return open + closing
+	}
+
+	inline := open + strings.Join(items, ", ") + closing
+	if s.Compact || (len(inline) <= maxInline && !strings.Contains(inline, "\n")) {
+		return inline
+	}
+
+	var b strings.Builder
+	b.WriteString(open + "\n")
+	for _, item := range items {
+		b.WriteString(strings.Repeat(s.Indent, depth+1) + item + ",\n")
+	}
+	b.WriteString(strings.Repeat(s.Indent, depth) + closing)
+	return b.String()
+}
+
+// sortedKeys returns the keys of a map in a stable order:
+// numbers and strings by value, anything else by its rendering
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sortValues(keys)
+	return keys
+}
+
+func sortValues(keys []reflect.Value) {
+	sort.Slice(keys, func(i, j int) bool {
+		return compareKeys(keys[i], keys[j]) < 0
+	})
+}
+
+func compareKeys(a, b reflect.Value) int {
+	for a.Kind() == reflect.Interface && !a.IsNil() {
+		a = a.Elem()
+	}
+	for b.Kind() == reflect.Interface && !b.IsNil() {
+		b = b.Elem()
+	}
+
+	if a.IsValid() && b.IsValid() && a.Kind() == b.Kind() {
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return cmp.Compare(a.Int(), b.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return cmp.Compare(a.Uint(), b.Uint())
+		case reflect.Float32, reflect.Float64:
+			return cmp.Compare(a.Float(), b.Float())
+		case reflect.String:
+			return cmp.Compare(a.String(), b.String())
+		}
+	}
+
+	p := Printer{Compact: true}
+	return cmp.Compare(p.sprintValue(a), p.sprintValue(b))
+}
+
+func (p Printer) sprintValue(v reflect.Value) string {
+	if p.Indent == "" {
+		p.Indent = "  "
+	}
+	return (&state{Printer: p, visiting: map[visit]bool{}}).render(v, 0)
+}
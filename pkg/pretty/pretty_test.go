@@ -0,0 +1,206 @@
+package pretty
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type point struct {
+	X, Y int
+}
+
+type node struct {
+	Name string
+	Next *node
+}
+
+func TestSprint(t *testing.T) {
+	var (
+		nilMap   map[string]int
+		nilSlice []int
+		nilPtr   *point
+		ch       = make(chan int)
+	)
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{nil, "nil"},
+		{42, "42"},
+		{uint8(7), "7"},
+		{-1.5, "-1.5"},
+		{float32(0.1), "0.1"},
+		{2 + 3i, "(2+3i)"},
+		{"a\"b\n", `"a\"b\n"`},
+		{true, "true"},
+		{time.October, "time.Month(10)"},
+		{90 * time.Second, "time.Duration(1m30s)"},
+		{time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC), "time.Time(2026-10-19T10:00:00Z)"},
+		{point{1, 2}, "pretty.point{X: 1, Y: 2}"},
+		{&point{1, 2}, "&pretty.point{X: 1, Y: 2}"},
+		{[]int{3, 1}, "[]int{3, 1}"},
+		{[2]bool{}, "[2]bool{false, false}"},
+		{map[string]int{"b": 2, "a": 1, "c": 3}, `map[string]int{"a": 1, "b": 2, "c": 3}`},
+		{map[int]string{10: "x", 9: "y"}, `map[int]string{9: "y", 10: "x"}`},
+		{map[point]bool{{2, 1}: true, {1, 2}: false}, "map[pretty.point]bool{\n  pretty.point{X: 1, Y: 2}: false,\n  pretty.point{X: 2, Y: 1}: true,\n}"},
+		{[]any{1, "a", nil}, `[]interface {}{1, "a", nil}`},
+		{nilMap, "nil"},
+		{nilSlice, "nil"},
+		{nilPtr, "nil"},
+		{ch, "<chan int>"},
+		{strings.ToUpper, "<func(string) string>"},
+		{map[string]int{}, "map[string]int{}"},
+	}
+	for _, tt := range tests {
+		if got := Sprint(tt.v); got != tt.want {
+			t.Errorf("Sprint(%#v) =\n%s\nwant\n%s", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestSprintLayout(t *testing.T) {
+	v := map[string][]point{
+		"long": {{1, 2}, {3, 4}, {5, 6}},
+		"one":  {{7, 8}},
+	}
+	want := `map[string][]pretty.point{
+  "long": []pretty.point{
+    pretty.point{X: 1, Y: 2},
+    pretty.point{X: 3, Y: 4},
+    pretty.point{X: 5, Y: 6},
+  },
+  "one": []pretty.point{pretty.point{X: 7, Y: 8}},
+}`
+	if got := Sprint(v); got != want {
+		t.Errorf("Sprint =\n%s\nwant\n%s", got, want)
+	}
+
+	tabs := Printer{Indent: "\t"}.Sprint(v)
+	if !strings.Contains(tabs, "\n\t\"long\"") || !strings.Contains(tabs, "\n\t\tpretty.point{X: 1") {
+		t.Errorf("Indent is not used:\n%s", tabs)
+	}
+	compact := Printer{Compact: true}.Sprint(v)
+	if strings.Contains(compact, "\n") {
+		t.Errorf("Compact spans lines:\n%s", compact)
+	}
+
+	var b bytes.Buffer
+	if err := Fprint(&b, point{}); err != nil || b.String() != "pretty.point{X: 0, Y: 0}\n" {
+		t.Errorf("Fprint = %q, %v", b.String(), err)
+	}
+}
+
+func TestSprintCycles(t *testing.T) {
+	a := &node{Name: "a"}
+	a.Next = &node{Name: "b", Next: a}
+	compact := Printer{Compact: true}
+	if got, want := compact.Sprint(a), `&pretty.node{Name: "a", Next: &pretty.node{Name: "b", Next: <cycle *pretty.node>}}`; got != want {
+		t.Errorf("Sprint =\n%s\nwant\n%s", got, want)
+	}
+
+	m := map[string]any{}
+	m["self"] = m
+	if got := compact.Sprint(m); got != `map[string]interface {}{"self": <cycle map[string]interface {}>}` {
+		t.Errorf("Sprint = %s", got)
+	}
+
+	// The same value twice side by side is not a cycle
+	shared := &point{1, 2}
+	if got := Sprint([]*point{shared, shared}); strings.Contains(got, "cycle") {
+		t.Errorf("Sprint = %s", got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	type config struct {
+		Name  string
+		Tags  map[string]string
+		Ports []int
+		Next  *config
+	}
+	a := config{Name: "a", Tags: map[string]string{"env": "prod", "old": "x"}, Ports: []int{80, 443}, Next: &config{Name: "n"}}
+	b := config{Name: "b", Tags: map[string]string{"env": "dev", "new": "y"}, Ports: []int{80}, Next: &config{Name: "m"}}
+
+	want := Diffs{
+		{".Name", `"a"`, `"b"`},
+		{`.Tags["env"]`, `"prod"`, `"dev"`},
+		{`.Tags["new"]`, Missing, `"y"`},
+		{`.Tags["old"]`, `"x"`, Missing},
+		{".Ports[1]", "443", Missing},
+		{".Next.Name", `"n"`, `"m"`},
+	}
+	if got := Diff(a, b); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff =\n%s\nwant\n%s", got, want)
+	}
+	if Diff(a, a) != nil {
+		t.Error("a value differs from itself")
+	}
+
+	tests := []struct {
+		a, b any
+		want string
+	}{
+		{nil, 1, "nil != 1"},
+		{[]int(nil), []int{}, "nil != []int{}"},
+		{map[string]int(nil), map[string]int{}, "nil != map[string]int{}"},
+		{(*point)(nil), &point{}, "nil != &pretty.point{X: 0, Y: 0}"},
+		{[]any{1}, []any{"1"}, `[0]: 1 != "1"`},
+	}
+	for _, tt := range tests {
+		diffs := Diff(tt.a, tt.b)
+		if len(diffs) != 1 || diffs.String() != tt.want {
+			t.Errorf("Diff(%#v, %#v) = %q, want %q", tt.a, tt.b, diffs.String(), tt.want)
+		}
+	}
+
+	x := &node{Name: "x"}
+	x.Next = x
+	y := &node{Name: "x"}
+	y.Next = &node{Name: "y", Next: y}
+	if got := Diff(x, y).String(); got != `.Next.Name: "x" != "y"` {
+		t.Errorf("Diff of cyclic values = %q", got)
+	}
+
+	// Two slices starting at the same address are still compared when their lengths differ
+	type pair struct{ Head, All []int }
+	s, u := []int{1, 2, 3}, []int{1, 9, 9, 9}
+	want = Diffs{
+		{".All[1]", "2", "9"},
+		{".All[2]", "3", "9"},
+		{".All[3]", Missing, "9"},
+	}
+	if got := Diff(pair{s[:1], s}, pair{u[:1], u}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff of slices sharing their start =\n%s\nwant\n%s", got, want)
+	}
+
+	loop := []any{nil}
+	loop[0] = loop
+	if got := Diff(loop, loop); got != nil {
+		t.Errorf("Diff of a slice holding itself = %q", got)
+	}
+}
+
+// TestDiffDeepEqual checks that Diff finds no difference exactly when "reflect.DeepEqual" says equal
+func TestDiffDeepEqual(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	value := func() any {
+		m := map[string][]int{}
+		for range rng.IntN(3) {
+			k := string(rune('a' + rng.IntN(3)))
+			for range rng.IntN(3) {
+				m[k] = append(m[k], rng.IntN(2))
+			}
+		}
+		return m
+	}
+	for range 2000 {
+		a, b := value(), value()
+		if equal, diffs := reflect.DeepEqual(a, b), Diff(a, b); equal != (diffs == nil) {
+			t.Fatalf("Diff(%v, %v) = %v but DeepEqual = %v", a, b, diffs, equal)
+		}
+	}
+}
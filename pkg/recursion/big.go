@@ -0,0 +1,97 @@
+// Package recursion grows the "fact" function of the recursion example
+// into arbitrary-precision combinatorics with "math/big",
+// and provides helpers to memoize recursive functions
+// and to evaluate deep recursions without growing the goroutine stack.
+package recursion
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Factorial returns "n!" exactly, it panics when "n" is negative.
+// The product is split in halves so that the big multiplications
+// work on numbers of similar sizes, which is much faster than multiplying one by one
+func Factorial(n int) *big.Int {
+	if n < 0 {
+		panic(fmt.Sprintf("recursion: factorial of negative number %d", n))
+	}
+	if n < 2 {
+		return big.NewInt(1)
+	}
+	return product(2, int64(n))
+}
+
+// product returns "lo × (lo+1) × … × hi"
+func product(lo, hi int64) *big.Int {
+	switch {
+	case lo > hi:
+		return big.NewInt(1)
+	case lo == hi:
+		return big.NewInt(lo)
+	case hi-lo == 1:
+		return new(big.Int).Mul(big.NewInt(lo), big.NewInt(hi))
+	}
+	mid := (lo + hi) / 2
+	return new(big.Int).Mul(product(lo, mid), product(mid+1, hi))
+}
+
+// Fibonacci returns the "n"-th Fibonacci number, F(0) = 0 and F(1) = 1,
+// it panics when "n" is negative. It uses the "fast doubling" identities
+//
+//	F(2k)   = F(k) × (2×F(k+1) − F(k))
+//	F(2k+1) = F(k)² + F(k+1)²
+//
+// walking the bits of "n", so it needs O(log n) big multiplications
+func Fibonacci(n int) *big.Int {
+	if n < 0 {
+		panic(fmt.Sprintf("recursion: Fibonacci of negative number %d", n))
+	}
+
+	a, b := big.NewInt(0), big.NewInt(1) // F(k), F(k+1) with k = 0
+	t := new(big.Int)
+	for bit := highestBit(n); bit >= 0; bit-- {
+		// c = F(2k), d = F(2k+1)
+		c := new(big.Int).Lsh(b, 1)
+		c.Sub(c, a).Mul(c, a)
+		d := new(big.Int).Mul(a, a)
+		d.Add(d, t.Mul(b, b))
+
+		if n>>bit&1 == 0 {
+			a, b = c, d
+		} else {
+			a, b = d, c.Add(c, d)
+		}
+	}
+	return a
+}
+
+func highestBit(n int) int {
+	bit := -1
+	for ; n > 0; n >>= 1 {
+		bit++
+	}
+	return bit
+}
+
+// Binomial returns "n choose k", the number of ways to pick "k" items among "n",
+// 0 when "k" is out of range. It panics when "n" is negative
+func Binomial(n, k int) *big.Int {
+	if n < 0 {
+		panic(fmt.Sprintf("recursion: binomial coefficient of negative number %d", n))
+	}
+	if k < 0 || k > n {
+		return big.NewInt(0)
+	}
+
+	// C(n, k) = C(n, n-k), the shorter product is cheaper
+	k = min(k, n-k)
+
+	// After step i the result is C(n-k+i, i), always an integer, so the division is exact
+	result := big.NewInt(1)
+	for i := 1; i <= k; i++ {
+		result.Mul(result, big.NewInt(int64(n-k+i)))
+		result.Quo(result, big.NewInt(int64(i)))
+	}
+	return result
+}
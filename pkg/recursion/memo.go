@@ -0,0 +1,120 @@
+package recursion
+
+// Memoize returns a function that computes "f" once per argument and remembers the result.
+// Recursive calls inside "f" go through the argument "self", so they are memoized too:
+//
+//	fib := recursion.Memoize(func(self func(int) int, n int) int {
+//		if n < 2 {
+//			return n
+//		}
+//		return self(n-1) + self(n-2)
+//	})
+//
+// The returned function is not safe for concurrent use
+func Memoize[K comparable, V any](f func(self func(K) V, k K) V) func(K) V {
+	cache := make(map[K]V)
+
+	var self func(K) V
+	self = func(k K) V {
+		if v, ok := cache[k]; ok {
+			return v
+		}
+		v := f(self, k)
+		cache[k] = v
+		return v
+	}
+	return self
+}
+
+// Iterative evaluates a recursive definition with an explicit stack instead of the call stack,
+// so the depth of the recursion is only limited by memory.
+//
+// "deps" lists the arguments "k" directly depends on, and "combine" computes the result
+// for "k" from their results, given in the same order. Every argument is evaluated once.
+// The factorial becomes:
+//
+//	recursion.Iterative(n,
+//		func(n int) []int { if n == 0 { return nil }; return []int{n - 1} },
+//		func(n int, sub []int) int { if n == 0 { return 1 }; return n * sub[0] })
+//
+// The dependencies must not form a cycle, which would loop forever with plain recursion
+// and makes Iterative panic
+func Iterative[K comparable, V any](root K, deps func(K) []K, combine func(k K, sub []V) V) V {
+	type frame struct {
+		key  K
+		deps []K
+	}
+
+	done := make(map[K]V)
+	onStack := make(map[K]bool)
+	stack := []frame{{key: root, deps: deps(root)}}
+	onStack[root] = true
+
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+
+		// Push the first dependency that has not been evaluated yet
+		pushed := false
+		for _, d := range top.deps {
+			if _, ok := done[d]; ok {
+				continue
+			}
+			if onStack[d] {
+				panic("recursion: cyclic dependency")
+			}
+			onStack[d] = true
+			stack = append(stack, frame{key: d, deps: deps(d)})
+			pushed = true
+			break
+		}
+		if pushed {
+			continue
+		}
+
+		// Every dependency is known, evaluate this frame
+		sub := make([]V, len(top.deps))
+		for i, d := range top.deps {
+			sub[i] = done[d]
+		}
+		done[top.key] = combine(top.key, sub)
+		delete(onStack, top.key)
+		stack = stack[:len(stack)-1]
+	}
+	return done[root]
+}
+
+// Step is one step of a trampolined computation:
+// either a final value or a function returning the next step
+type Step[T any] struct {
+	value T
+	next  func() Step[T]
+}
+
+// Done returns a final step holding "v"
+func Done[T any](v T) Step[T] {
+	return Step[T]{value: v}
+}
+
+// Call returns a step that continues with "next",
+// used in place of a recursive tail call
+func Call[T any](next func() Step[T]) Step[T] {
+	return Step[T]{next: next}
+}
+
+// Trampoline runs the steps in a loop until a final value is reached,
+// so that a chain of tail calls runs in constant stack space:
+//
+//	var sum func(n, acc int) recursion.Step[int]
+//	sum = func(n, acc int) recursion.Step[int] {
+//		if n == 0 {
+//			return recursion.Done(acc)
+//		}
+//		return recursion.Call(func() recursion.Step[int] { return sum(n-1, acc+n) })
+//	}
+//	recursion.Trampoline(sum(1_000_000, 0))
+func Trampoline[T any](s Step[T]) T {
+	for s.next != nil {
+		s = s.next()
+	}
+	return s.value
+}
@@ -0,0 +1,154 @@
+package recursion
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFactorial(t *testing.T) {
+	want := big.NewInt(1)
+	for n := 0; n <= 5000; n++ {
+		if n > 0 {
+			want.Mul(want, big.NewInt(int64(n)))
+		}
+		if n >= 30 && n%250 != 0 && n != 5000 {
+			continue
+		}
+		if got := Factorial(n); got.Cmp(want) != 0 {
+			t.Fatalf("Factorial(%d) differs from the naive product", n)
+		}
+	}
+}
+
+func TestFibonacci(t *testing.T) {
+	a, b := big.NewInt(0), big.NewInt(1)
+	for n := 0; n <= 10000; n++ {
+		if n < 100 || n%500 == 0 || n == 10000 {
+			if got := Fibonacci(n); got.Cmp(a) != 0 {
+				t.Fatalf("Fibonacci(%d) = %s, want %s", n, got, a)
+			}
+		}
+		a.Add(a, b)
+		a, b = b, a
+	}
+}
+
+func TestBinomial(t *testing.T) {
+	// Build Pascal's triangle row by row: C(n, k) = C(n-1, k-1) + C(n-1, k)
+	row := []*big.Int{big.NewInt(1)}
+	for n := 0; n <= 300; n++ {
+		for k, want := range row {
+			if got := Binomial(n, k); got.Cmp(want) != 0 {
+				t.Fatalf("Binomial(%d, %d) = %s, want %s", n, k, got, want)
+			}
+		}
+
+		next := make([]*big.Int, len(row)+1)
+		next[0], next[len(row)] = big.NewInt(1), big.NewInt(1)
+		for k := 1; k < len(row); k++ {
+			next[k] = new(big.Int).Add(row[k-1], row[k])
+		}
+		row = next
+	}
+
+	for _, k := range []int{-1, 11} {
+		if got := Binomial(10, k); got.Sign() != 0 {
+			t.Errorf("Binomial(10, %d) = %s, want 0", k, got)
+		}
+	}
+}
+
+func TestNegativePanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"Factorial": func() { Factorial(-1) },
+		"Fibonacci": func() { Fibonacci(-1) },
+		"Binomial":  func() { Binomial(-1, 0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s(-1) did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestMemoize(t *testing.T) {
+	calls := 0
+	fib := Memoize(func(self func(int) int, n int) int {
+		calls++
+		if n < 2 {
+			return n
+		}
+		return self(n-1) + self(n-2)
+	})
+	if got := fib(80); got != 23416728348467685 {
+		t.Errorf("fib(80) = %d", got)
+	}
+	if calls != 81 {
+		t.Errorf("%d calls, want one per argument", calls)
+	}
+}
+
+func TestIterative(t *testing.T) {
+	// A recursion a million levels deep would need a huge goroutine stack
+	const n = 1_000_000
+	sum := Iterative(n,
+		func(n int) []int {
+			if n == 0 {
+				return nil
+			}
+			return []int{n - 1}
+		},
+		func(n int, sub []int) int {
+			if n == 0 {
+				return 0
+			}
+			return n + sub[0]
+		})
+	if sum != n*(n+1)/2 {
+		t.Errorf("sum = %d", sum)
+	}
+
+	// Shared dependencies are evaluated once
+	evaluated := map[int]int{}
+	fib := Iterative(90,
+		func(n int) []int {
+			if n < 2 {
+				return nil
+			}
+			return []int{n - 1, n - 2}
+		},
+		func(n int, sub []int) int {
+			evaluated[n]++
+			if n < 2 {
+				return n
+			}
+			return sub[0] + sub[1]
+		})
+	if fib != 2880067194370816120 || len(evaluated) != 91 || evaluated[45] != 1 {
+		t.Errorf("fib(90) = %d after %d evaluations", fib, len(evaluated))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("a cycle did not panic")
+		}
+	}()
+	Iterative(0, func(n int) []int { return []int{(n + 1) % 3} }, func(int, []int) int { return 0 })
+}
+
+func TestTrampoline(t *testing.T) {
+	var sum func(n, acc int) Step[int]
+	sum = func(n, acc int) Step[int] {
+		if n == 0 {
+			return Done(acc)
+		}
+		return Call(func() Step[int] { return sum(n-1, acc+n) })
+	}
+	if got := Trampoline(sum(1_000_000, 0)); got != 500000500000 {
+		t.Errorf("sum = %d", got)
+	}
+}
@@ -0,0 +1,65 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"strings"
+)
+
+// The standard library has no font rasterizer, labels in images use this 3x5 bitmap font.
+// It covers digits, upper case letters and the punctuation of numbers,
+// lower case letters are drawn in upper case and anything else as a blank
+const (
+	glyphWidth  = 3
+	glyphHeight = 5
+)
+
+// glyphs holds one row per line, the lowest three bits being the pixels from left to right
+var glyphs = map[rune][glyphHeight]uint8{
+	'0': {7, 5, 5, 5, 7}, '1': {2, 6, 2, 2, 7}, '2': {7, 1, 7, 4, 7}, '3': {7, 1, 7, 1, 7},
+	'4': {5, 5, 7, 1, 1}, '5': {7, 4, 7, 1, 7}, '6': {7, 4, 7, 5, 7}, '7': {7, 1, 1, 1, 1},
+	'8': {7, 5, 7, 5, 7}, '9': {7, 5, 7, 1, 7},
+	'A': {2, 5, 7, 5, 5}, 'B': {6, 5, 6, 5, 6}, 'C': {7, 4, 4, 4, 7}, 'D': {6, 5, 5, 5, 6},
+	'E': {7, 4, 6, 4, 7}, 'F': {7, 4, 6, 4, 4}, 'G': {7, 4, 5, 5, 7}, 'H': {5, 5, 7, 5, 5},
+	'I': {7, 2, 2, 2, 7}, 'J': {1, 1, 1, 5, 7}, 'K': {5, 5, 6, 5, 5}, 'L': {4, 4, 4, 4, 7},
+	'M': {5, 7, 7, 5, 5}, 'N': {6, 5, 5, 5, 5}, 'O': {2, 5, 5, 5, 2}, 'P': {6, 5, 6, 4, 4},
+	'Q': {2, 5, 5, 6, 3}, 'R': {6, 5, 6, 5, 5}, 'S': {7, 4, 7, 1, 7}, 'T': {7, 2, 2, 2, 2},
+	'U': {5, 5, 5, 5, 7}, 'V': {5, 5, 5, 5, 2}, 'W': {5, 5, 7, 7, 5}, 'X': {5, 5, 2, 5, 5},
+	'Y': {5, 5, 2, 2, 2}, 'Z': {7, 1, 2, 4, 7},
+	'.': {0, 0, 0, 0, 2}, ',': {0, 0, 0, 2, 4}, ':': {0, 2, 0, 2, 0}, '-': {0, 0, 7, 0, 0},
+	'+': {0, 2, 7, 2, 0}, '=': {0, 7, 0, 7, 0}, '(': {1, 2, 2, 2, 1}, ')': {4, 2, 2, 2, 4},
+	'/': {1, 1, 2, 4, 4}, '%': {5, 1, 2, 4, 5},
+}
+
+// drawText draws "text" centered on (cx, cy), each font pixel being "scale" pixels wide
+func drawText(img *image.NRGBA, text string, cx, cy int, c color.Color, scale int) {
+	text = strings.ToUpper(text)
+	n := len([]rune(text))
+
+	// Glyphs are separated by one blank column
+	width := (n*(glyphWidth+1) - 1) * scale
+	x := cx - width/2
+	y := cy - glyphHeight*scale/2
+
+	for _, r := range text {
+		g := glyphs[r]
+		for row := 0; row < glyphHeight; row++ {
+			for col := 0; col < glyphWidth; col++ {
+				if g[row]&(1<<(glyphWidth-1-col)) == 0 {
+					continue
+				}
+				fillRect(img, x+col*scale, y+row*scale, scale, c)
+			}
+		}
+		x += (glyphWidth + 1) * scale
+	}
+}
+
+func fillRect(img *image.NRGBA, x, y, size int, c color.Color) {
+	r := image.Rect(x, y, x+size, y+size).Intersect(img.Bounds())
+	for py := r.Min.Y; py < r.Max.Y; py++ {
+		for px := r.Min.X; px < r.Max.X; px++ {
+			img.Set(px, py, c)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/hieuvp/learning-golang/pkg/shape"
+)
+
+// samples is the number of sub-pixel samples per axis, 16 samples per pixel smooth the edges
+const samples = 4
+
+// Image rasterizes the scene.
+// Every shape is sampled through its Contains method, so any shape can be drawn,
+// and a sample belongs to the stroke when moving it by half the stroke width
+// in some direction crosses the outline
+func (s *Scene) Image() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, s.Width, s.Height))
+	if s.Background != nil {
+		bg := color.NRGBAModel.Convert(s.Background).(color.NRGBA)
+		for i := 0; i < len(img.Pix); i += 4 {
+			img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = bg.R, bg.G, bg.B, bg.A
+		}
+	}
+
+	v := s.viewport()
+	for _, item := range s.Items {
+		rasterize(img, v, item)
+	}
+	for _, item := range s.Items {
+		if label := s.label(item); label != "" {
+			x, y := v.toPixel(item.Shape.Bounds().Center())
+			drawText(img, label, int(math.Round(x)), int(math.Round(y)), color.Black, 2)
+		}
+	}
+	return img
+}
+
+// PNG writes the rasterized scene as a PNG image
+func (s *Scene) PNG(w io.Writer) error {
+	return png.Encode(w, s.Image())
+}
+
+func rasterize(img *image.NRGBA, v viewport, item Item) {
+	st := item.Style
+	width := st.StrokeWidth
+	if st.Stroke != nil && width == 0 {
+		width = 1
+	}
+	if st.Stroke == nil {
+		width = 0
+	}
+
+	// Only visit the pixels around the shape
+	box := item.Shape.Bounds()
+	x0, y1 := v.toPixel(box.Min)
+	x1, y0 := v.toPixel(box.Max)
+	margin := width + 1
+	r := image.Rect(int(x0-margin), int(y0-margin), int(x1+margin)+1, int(y1+margin)+1).Intersect(img.Bounds())
+
+	// Half the stroke width in world units, and the directions probed around each sample
+	reach := width / 2 / v.scale
+	var probes []shape.Point
+	if width > 0 {
+		for k := 0; k < 8; k++ {
+			probes = append(probes, shape.Point{X: reach}.Rotate(float64(k)*math.Pi/4))
+		}
+	}
+
+	for py := r.Min.Y; py < r.Max.Y; py++ {
+		for px := r.Min.X; px < r.Max.X; px++ {
+			var fill, stroke int
+			for sy := 0; sy < samples; sy++ {
+				for sx := 0; sx < samples; sx++ {
+					p := v.toWorld(float64(px)+(float64(sx)+0.5)/samples, float64(py)+(float64(sy)+0.5)/samples)
+					inside := item.Shape.Contains(p)
+					if inside {
+						fill++
+					}
+					for _, d := range probes {
+						if item.Shape.Contains(p.Add(d)) != inside {
+							stroke++
+							break
+						}
+					}
+				}
+			}
+
+			if st.Fill != nil && fill > 0 {
+				blend(img, px, py, st.Fill, float64(fill)/(samples*samples))
+			}
+			if st.Stroke != nil && stroke > 0 {
+				blend(img, px, py, st.Stroke, float64(stroke)/(samples*samples))
+			}
+		}
+	}
+}
+
+// blend paints "c" over the pixel at (x, y) with the given coverage, "source over" compositing
+func blend(img *image.NRGBA, x, y int, c color.Color, coverage float64) {
+	src := color.NRGBAModel.Convert(c).(color.NRGBA)
+	i := img.PixOffset(x, y)
+	dst := img.Pix[i : i+4 : i+4]
+
+	sa := float64(src.A) / 0xff * coverage
+	da := float64(dst[3]) / 0xff
+	oa := sa + da*(1-sa)
+	if oa == 0 {
+		return
+	}
+
+	mix := func(s, d uint8) uint8 {
+		return uint8(math.Round((float64(s)*sa + float64(d)*da*(1-sa)) / oa))
+	}
+	dst[0], dst[1], dst[2] = mix(src.R, dst[0]), mix(src.G, dst[1]), mix(src.B, dst[2])
+	dst[3] = uint8(math.Round(oa * 0xff))
+}
@@ -0,0 +1,92 @@
+package render
+
+import (
+	"bytes"
+	"flag"
+	"image/color"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hieuvp/learning-golang/pkg/shape"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+var (
+	red   = color.NRGBA{R: 0xd6, G: 0x27, B: 0x28, A: 0xff}
+	blue  = color.NRGBA{R: 0x1f, G: 0x77, B: 0xb4, A: 0x80}
+	green = color.NRGBA{R: 0x2c, G: 0xa0, B: 0x2c, A: 0xff}
+)
+
+// scenes are the golden scenes, one per kind of shape and one with labels
+func scenes() map[string]*Scene {
+	style := Style{Fill: blue, Stroke: color.Black, StrokeWidth: 1}
+	single := func(sh shape.Shape) *Scene {
+		return NewScene(64, 48).Add(sh, style)
+	}
+
+	labeled := NewScene(320, 160)
+	labeled.Labels = true
+	labeled.Add(shape.Circle{Center: shape.Pt(0, 0), Radius: 2}, Style{Fill: red})
+	labeled.Add(shape.Rect{Origin: shape.Pt(6, -1), Width: 3, Height: 2, Angle: math.Pi / 8}, Style{Stroke: green, StrokeWidth: 2})
+	labeled.AddLabeled(shape.Triangle{A: shape.Pt(-2, 3), B: shape.Pt(2, 3), C: shape.Pt(0, 5)}, DefaultStyle, "TRI")
+
+	return map[string]*Scene{
+		"circle":          single(shape.Circle{Center: shape.Pt(0, 0), Radius: 1}),
+		"ellipse":         single(shape.Ellipse{Center: shape.Pt(0, 0), RX: 2, RY: 1, Angle: math.Pi / 6}),
+		"polygon":         single(shape.Poly(shape.Pt(0, 0), shape.Pt(2, 0), shape.Pt(2, 1), shape.Pt(1, 1), shape.Pt(1, 2), shape.Pt(0, 2))),
+		"triangle":        single(shape.Triangle{A: shape.Pt(0, 0), B: shape.Pt(4, 0), C: shape.Pt(0, 3)}),
+		"rect":            single(shape.Rect{Origin: shape.Pt(0, 0), Width: 3, Height: 1, Angle: math.Pi / 6}),
+		"regular_polygon": single(shape.RegularPolygon{Center: shape.Pt(0, 0), Sides: 5, Radius: 1}),
+		"labeled":         labeled,
+	}
+}
+
+func TestGolden(t *testing.T) {
+	for name, scene := range scenes() {
+		t.Run(name, func(t *testing.T) {
+			var svg, png bytes.Buffer
+			if err := scene.SVG(&svg); err != nil {
+				t.Fatal(err)
+			}
+			if err := scene.PNG(&png); err != nil {
+				t.Fatal(err)
+			}
+
+			golden(t, name+".svg", svg.Bytes())
+			golden(t, name+".png", png.Bytes())
+		})
+	}
+}
+
+// golden compares "got" with the content of testdata/"name", or rewrites it with -update
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v, run the tests with -update to create it", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs from the golden file (%d bytes, want %d), check it and run the tests with -update", name, len(got), len(want))
+	}
+}
+
+func TestEmptyScene(t *testing.T) {
+	img := NewScene(4, 3).Image()
+	if b := img.Bounds(); b.Dx() != 4 || b.Dy() != 3 {
+		t.Fatalf("bounds %v", b)
+	}
+	if c := img.NRGBAAt(1, 1); c != (color.NRGBA{0xff, 0xff, 0xff, 0xff}) {
+		t.Errorf("background %v", c)
+	}
+}
@@ -0,0 +1,120 @@
+// Package render draws scenes of shapes as SVG documents or PNG images,
+// using nothing but the standard library.
+//
+// Shapes are drawn in the order they were added, with an optional label
+// showing their area and perimeter, the drawing counterpart of "measure"
+// in the interfaces example. The world is fitted into the canvas keeping its
+// aspect ratio, with the Y axis pointing up as in the shape package.
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hieuvp/learning-golang/pkg/shape"
+)
+
+// Style controls how a shape is painted, a nil color is not painted at all
+type Style struct {
+	Fill        color.Color
+	Stroke      color.Color
+	StrokeWidth float64
+}
+
+// DefaultStyle is a black outline without fill
+var DefaultStyle = Style{Stroke: color.Black, StrokeWidth: 1}
+
+// Item is a shape placed in a scene
+type Item struct {
+	Shape shape.Shape
+	Style Style
+
+	// Label is drawn at the center of the shape,
+	// when empty and Scene.Labels is set it shows the area and perimeter
+	Label string
+}
+
+// Scene is an ordered list of shapes and the canvas they are drawn on
+type Scene struct {
+	Items []Item
+
+	// Width and Height are the size of the canvas in pixels
+	Width, Height int
+
+	// Padding is the margin in pixels kept free around the shapes
+	Padding float64
+
+	// Background fills the canvas before anything is drawn, nil keeps it transparent
+	Background color.Color
+
+	// Labels shows the area and perimeter of shapes that have no label of their own
+	Labels bool
+}
+
+// NewScene returns an empty scene with a white background
+func NewScene(width, height int) *Scene {
+	return &Scene{Width: width, Height: height, Padding: 10, Background: color.White}
+}
+
+// Add appends a shape drawn with the given style
+func (s *Scene) Add(sh shape.Shape, style Style) *Scene {
+	s.Items = append(s.Items, Item{Shape: sh, Style: style})
+	return s
+}
+
+// AddLabeled appends a shape drawn with the given style and label
+func (s *Scene) AddLabeled(sh shape.Shape, style Style, label string) *Scene {
+	s.Items = append(s.Items, Item{Shape: sh, Style: style, Label: label})
+	return s
+}
+
+// label returns the text drawn for "item", empty for none
+func (s *Scene) label(item Item) string {
+	if item.Label != "" || !s.Labels {
+		return item.Label
+	}
+	return MeasureLabel(item.Shape)
+}
+
+// MeasureLabel formats the area and perimeter of a shape
+func MeasureLabel(sh shape.Shape) string {
+	return fmt.Sprintf("A=%.2f P=%.2f", sh.Area(), sh.Perimeter())
+}
+
+// viewport maps world coordinates to pixels, flipping the Y axis
+type viewport struct {
+	box        shape.Box
+	scale      float64
+	offX, offY float64
+}
+
+func (s *Scene) viewport() viewport {
+	shapes := make([]shape.Shape, len(s.Items))
+	for i, item := range s.Items {
+		shapes[i] = item.Shape
+	}
+	box := shape.Bounds(shapes...)
+
+	w := math.Max(box.Width(), shape.Epsilon)
+	h := math.Max(box.Height(), shape.Epsilon)
+	availW := math.Max(float64(s.Width)-2*s.Padding, 1)
+	availH := math.Max(float64(s.Height)-2*s.Padding, 1)
+	scale := math.Min(availW/w, availH/h)
+
+	// Center the drawing on the canvas
+	return viewport{
+		box:   box,
+		scale: scale,
+		offX:  (float64(s.Width) - box.Width()*scale) / 2,
+		offY:  (float64(s.Height) - box.Height()*scale) / 2,
+	}
+}
+
+func (v viewport) toPixel(p shape.Point) (x, y float64) {
+	return (p.X-v.box.Min.X)*v.scale + v.offX, (v.box.Max.Y-p.Y)*v.scale + v.offY
+}
+
+func (v viewport) toWorld(x, y float64) shape.Point {
+	return shape.Point{X: (x-v.offX)/v.scale + v.box.Min.X, Y: v.box.Max.Y - (y-v.offY)/v.scale}
+}
@@ -0,0 +1,146 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/hieuvp/learning-golang/pkg/shape"
+)
+
+// outlineSteps is the number of points used to trace shapes the renderer does not know
+const outlineSteps = 180
+
+// polygoner is implemented by every shape that can be turned into a polygon
+type polygoner interface {
+	Polygon() shape.Polygon
+}
+
+// SVG writes the scene as a standalone SVG document
+func (s *Scene) SVG(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	v := s.viewport()
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		s.Width, s.Height, s.Width, s.Height)
+	if s.Background != nil {
+		fmt.Fprintf(bw, `  <rect width="100%%" height="100%%"%s/>`+"\n", paint("fill", s.Background))
+	}
+
+	for _, item := range s.Items {
+		fmt.Fprintf(bw, "  %s\n", svgShape(v, item))
+	}
+	for _, item := range s.Items {
+		label := s.label(item)
+		if label == "" {
+			continue
+		}
+		x, y := v.toPixel(item.Shape.Bounds().Center())
+		fmt.Fprintf(bw, `  <text x="%s" y="%s" font-family="monospace" font-size="12" text-anchor="middle" dominant-baseline="middle">%s</text>`+"\n",
+			num(x), num(y), html.EscapeString(label))
+	}
+
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+// svgShape returns the element drawing a single shape
+func svgShape(v viewport, item Item) string {
+	style := svgStyle(item.Style)
+
+	switch sh := item.Shape.(type) {
+	case shape.Circle:
+		x, y := v.toPixel(sh.Center)
+		return fmt.Sprintf(`<circle cx="%s" cy="%s" r="%s"%s/>`, num(x), num(y), num(sh.Radius*v.scale), style)
+	case shape.Ellipse:
+		// The Y axis is flipped, so counterclockwise angles become clockwise ones
+		x, y := v.toPixel(sh.Center)
+		deg := -sh.Angle * 180 / math.Pi
+		return fmt.Sprintf(`<ellipse cx="%s" cy="%s" rx="%s" ry="%s" transform="rotate(%s %s %s)"%s/>`,
+			num(x), num(y), num(sh.RX*v.scale), num(sh.RY*v.scale), num(deg), num(x), num(y), style)
+	case shape.Polygon:
+		return svgPolygon(v, sh.Points, style)
+	case polygoner:
+		return svgPolygon(v, sh.Polygon().Points, style)
+	}
+	return svgPolygon(v, traceOutline(item.Shape), style)
+}
+
+func svgPolygon(v viewport, points []shape.Point, style string) string {
+	coords := make([]string, len(points))
+	for i, p := range points {
+		x, y := v.toPixel(p)
+		coords[i] = num(x) + "," + num(y)
+	}
+	return fmt.Sprintf(`<polygon points="%s"%s/>`, strings.Join(coords, " "), style)
+}
+
+func svgStyle(st Style) string {
+	width := st.StrokeWidth
+	if st.Stroke != nil && width == 0 {
+		width = 1
+	}
+
+	var b strings.Builder
+	b.WriteString(paint("fill", st.Fill))
+	b.WriteString(paint("stroke", st.Stroke))
+	if st.Stroke != nil {
+		fmt.Fprintf(&b, ` stroke-width="%s"`, num(width))
+	}
+	return b.String()
+}
+
+// paint returns the attributes painting "c" as a fill or a stroke
+func paint(attr string, c color.Color) string {
+	if c == nil {
+		return fmt.Sprintf(` %s="none"`, attr)
+	}
+
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	s := fmt.Sprintf(` %s="#%02x%02x%02x"`, attr, n.R, n.G, n.B)
+	if n.A != 0xff {
+		s += fmt.Sprintf(` %s-opacity="%s"`, attr, num(float64(n.A)/0xff))
+	}
+	return s
+}
+
+// traceOutline approximates the outline of an unknown shape
+// by searching, along rays from the center of its bounds, where it stops.
+// It is exact enough for shapes that are star-shaped around that center
+func traceOutline(sh shape.Shape) []shape.Point {
+	box := sh.Bounds()
+	c := box.Center()
+	reach := math.Hypot(box.Width(), box.Height())
+
+	points := make([]shape.Point, 0, outlineSteps)
+	for i := 0; i < outlineSteps; i++ {
+		dir := shape.Point{X: 1}.Rotate(2 * math.Pi * float64(i) / outlineSteps)
+
+		// Bisect between a distance inside the shape and one outside of it
+		lo, hi := 0.0, reach
+		for k := 0; k < 32; k++ {
+			mid := (lo + hi) / 2
+			if sh.Contains(c.Add(dir.Mul(mid))) {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		points = append(points, c.Add(dir.Mul(lo)))
+	}
+	return points
+}
+
+// num formats a coordinate without trailing zeros, keeping the output stable and short
+func num(f float64) string {
+	s := fmt.Sprintf("%.2f", f)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,224 @@
+package shape
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// TypeField is the name of the discriminator field added to encoded shapes,
+// e.g. {"type":"circle","center":{"x":0,"y":0},"radius":5}
+const TypeField = "type"
+
+// ErrUnknownType is returned, wrapped, when decoding a shape whose type is not registered
+var ErrUnknownType = errors.New("unknown shape type")
+
+// Registry maps discriminator names to concrete shape types.
+// A Shape value carries no type information once encoded,
+// so every concrete type must be registered under a name to be decoded back
+type Registry struct {
+	mu     sync.RWMutex
+	byName map[string]reflect.Type
+	byType map[reflect.Type]string
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{byName: map[string]reflect.Type{}, byType: map[reflect.Type]string{}}
+}
+
+// DefaultRegistry knows every shape of this package,
+// it is used by the package-level functions
+var DefaultRegistry = newDefaultRegistry()
+
+func newDefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.MustRegister("circle", Circle{})
+	r.MustRegister("ellipse", Ellipse{})
+	r.MustRegister("polygon", Polygon{})
+	r.MustRegister("triangle", Triangle{})
+	r.MustRegister("rect", Rect{})
+	r.MustRegister("regular_polygon", RegularPolygon{})
+	return r
+}
+
+// Register associates "name" with the concrete type of "prototype".
+// The type may be a struct or a pointer, it is decoded into the same kind of value
+func (r *Registry) Register(name string, prototype Shape) error {
+	if name == "" {
+		return errors.New("shape: empty type name")
+	}
+	if prototype == nil {
+		return fmt.Errorf("shape: nil prototype for type %q", name)
+	}
+	t := reflect.TypeOf(prototype)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.byName[name]; ok {
+		return fmt.Errorf("shape: type name %q is already registered for %v", name, existing)
+	}
+	if existing, ok := r.byType[t]; ok {
+		return fmt.Errorf("shape: %v is already registered as %q", t, existing)
+	}
+	r.byName[name] = t
+	r.byType[t] = name
+	return nil
+}
+
+// MustRegister is like Register but panics on error, for use in package initialization
+func (r *Registry) MustRegister(name string, prototype Shape) {
+	if err := r.Register(name, prototype); err != nil {
+		panic(err)
+	}
+}
+
+// Name returns the name "s" is registered under
+func (r *Registry) Name(s Shape) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	name, ok := r.byType[reflect.TypeOf(s)]
+	return name, ok
+}
+
+// Marshal encodes "s" as a JSON object with its type name in the "type" field
+func (r *Registry) Marshal(s Shape) ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	name, ok := r.Name(s)
+	if !ok {
+		return nil, fmt.Errorf("shape: %T is not registered", s)
+	}
+
+	body, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	body = bytes.TrimSpace(body)
+	if len(body) < 2 || body[0] != '{' {
+		return nil, fmt.Errorf("shape: %T does not encode to a JSON object", s)
+	}
+
+	// Splice the discriminator in front of the fields of the object
+	var buf bytes.Buffer
+	buf.WriteString(`{"` + TypeField + `":`)
+	quoted, _ := json.Marshal(name)
+	buf.Write(quoted)
+	if rest := bytes.TrimSpace(body[1:]); rest[0] != '}' {
+		buf.WriteByte(',')
+	}
+	buf.Write(body[1:])
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes a shape encoded by Marshal into a value of its registered type
+func (r *Registry) Unmarshal(data []byte) (Shape, error) {
+	var head map[string]json.RawMessage
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, fmt.Errorf("shape: %w", err)
+	}
+	if head == nil {
+		return nil, nil
+	}
+
+	raw, ok := head[TypeField]
+	if !ok {
+		return nil, fmt.Errorf("shape: missing %q field", TypeField)
+	}
+	var name string
+	if err := json.Unmarshal(raw, &name); err != nil {
+		return nil, fmt.Errorf("shape: %q field must be a string, got %s", TypeField, raw)
+	}
+
+	r.mu.RLock()
+	t, ok := r.byName[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("shape: %w %q", ErrUnknownType, name)
+	}
+
+	// Decode into a new value of the registered type, through a pointer to it
+	isPtr := t.Kind() == reflect.Pointer
+	if isPtr {
+		t = t.Elem()
+	}
+	v := reflect.New(t)
+	if err := json.Unmarshal(data, v.Interface()); err != nil {
+		return nil, fmt.Errorf("shape: decoding %q: %w", name, err)
+	}
+	if !isPtr {
+		v = v.Elem()
+	}
+	return v.Interface().(Shape), nil
+}
+
+// MarshalSlice encodes the shapes as a JSON array
+func (r *Registry) MarshalSlice(shapes []Shape) ([]byte, error) {
+	items := make([]json.RawMessage, len(shapes))
+	for i, s := range shapes {
+		data, err := r.Marshal(s)
+		if err != nil {
+			return nil, fmt.Errorf("shape %d: %w", i, err)
+		}
+		items[i] = data
+	}
+	return json.Marshal(items)
+}
+
+// UnmarshalSlice decodes a JSON array of shapes, reporting the index of the first bad one
+func (r *Registry) UnmarshalSlice(data []byte) ([]Shape, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("shape: %w", err)
+	}
+
+	shapes := make([]Shape, len(items))
+	for i, item := range items {
+		s, err := r.Unmarshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("shape %d: %w", i, err)
+		}
+		shapes[i] = s
+	}
+	return shapes, nil
+}
+
+// Register associates "name" with the type of "prototype" in the DefaultRegistry
+func Register(name string, prototype Shape) error {
+	return DefaultRegistry.Register(name, prototype)
+}
+
+// Marshal encodes "s" with the DefaultRegistry
+func Marshal(s Shape) ([]byte, error) {
+	return DefaultRegistry.Marshal(s)
+}
+
+// Unmarshal decodes a shape with the DefaultRegistry
+func Unmarshal(data []byte) (Shape, error) {
+	return DefaultRegistry.Unmarshal(data)
+}
+
+// Shapes is a list of shapes that encodes to and decodes from JSON
+// through the DefaultRegistry, so it can be embedded in other types
+type Shapes []Shape
+
+// MarshalJSON implements "json.Marshaler"
+func (s Shapes) MarshalJSON() ([]byte, error) {
+	return DefaultRegistry.MarshalSlice(s)
+}
+
+// UnmarshalJSON implements "json.Unmarshaler"
+func (s *Shapes) UnmarshalJSON(data []byte) error {
+	shapes, err := DefaultRegistry.UnmarshalSlice(data)
+	if err != nil {
+		return err
+	}
+	*s = shapes
+	return nil
+}
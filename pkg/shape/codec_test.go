@@ -0,0 +1,192 @@
+package shape
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarshalRoundTrip(t *testing.T) {
+	shapes := []Shape{
+		Circle{Pt(1, 2), 3},
+		Ellipse{Pt(0, 0), 2, 1, math.Pi / 4},
+		Poly(Pt(0, 0), Pt(1, 0), Pt(0, 1)),
+		Triangle{Pt(0, 0), Pt(4, 0), Pt(0, 3)},
+		Rect{Pt(1, 2), 4, 2, 0},
+		RegularPolygon{Pt(0, 0), 6, 1, 0.5},
+	}
+	for _, s := range shapes {
+		name, ok := DefaultRegistry.Name(s)
+		if !ok {
+			t.Fatalf("%T is not registered", s)
+		}
+		data, err := Marshal(s)
+		if err != nil {
+			t.Fatalf("Marshal(%#v): %v", s, err)
+		}
+		if !strings.HasPrefix(string(data), `{"type":"`+name+`",`) {
+			t.Errorf("Marshal(%#v) = %s, want the type %q first", s, data, name)
+		}
+
+		got, err := Unmarshal(data)
+		if err != nil {
+			t.Fatalf("Unmarshal(%s): %v", data, err)
+		}
+		if !reflect.DeepEqual(got, s) {
+			t.Errorf("Unmarshal(%s) = %#v, want %#v", data, got, s)
+		}
+	}
+
+	data, err := DefaultRegistry.MarshalSlice(shapes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := DefaultRegistry.UnmarshalSlice(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, shapes) {
+		t.Errorf("UnmarshalSlice(%s) = %#v, want %#v", data, got, shapes)
+	}
+}
+
+func TestMarshalEncoding(t *testing.T) {
+	data, err := Marshal(Circle{Pt(0, 1), 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"type":"circle","center":{"x":0,"y":1},"radius":5}`; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	data, err = Marshal(nil)
+	if err != nil || string(data) != "null" {
+		t.Errorf("Marshal(nil) = %s, %v, want null", data, err)
+	}
+	if _, err := NewRegistry().Marshal(Circle{}); err == nil {
+		t.Error("Marshal of an unregistered type should fail")
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{`{"type":"hexagon","sides":6}`, `unknown shape type "hexagon"`},
+		{`{"center":{"x":0,"y":0},"radius":1}`, `missing "type" field`},
+		{`{"type":7,"radius":1}`, `"type" field must be a string, got 7`},
+		{`{"type":"circle","radius":"big"}`, `decoding "circle"`},
+		{`[1, 2]`, "cannot unmarshal array"},
+		{`{"type":`, "unexpected end of JSON input"},
+	}
+	for _, tt := range tests {
+		s, err := Unmarshal([]byte(tt.data))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Unmarshal(%s) = %#v, %v, want an error containing %q", tt.data, s, err, tt.want)
+		}
+	}
+
+	if _, err := Unmarshal([]byte(`{"type":"hexagon"}`)); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("Unmarshal of an unknown type: %v is not ErrUnknownType", err)
+	}
+	if _, err := DefaultRegistry.UnmarshalSlice([]byte(`[{"type":"circle"},{"type":"hexagon"}]`)); err == nil ||
+		!errors.Is(err, ErrUnknownType) || !strings.HasPrefix(err.Error(), "shape 1: ") {
+		t.Errorf("UnmarshalSlice with an unknown type at index 1: %v", err)
+	}
+
+	s, err := Unmarshal([]byte("null"))
+	if s != nil || err != nil {
+		t.Errorf("Unmarshal(null) = %#v, %v, want nil, nil", s, err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	r := NewRegistry()
+	if err := r.Register("disc", Circle{}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		prototype Shape
+		want      string
+	}{
+		{"disc", Rect{}, `type name "disc" is already registered for shape.Circle`},
+		{"round", Circle{}, `shape.Circle is already registered as "disc"`},
+		{"", Rect{}, "empty type name"},
+		{"nothing", nil, `nil prototype for type "nothing"`},
+	}
+	for _, tt := range tests {
+		err := r.Register(tt.name, tt.prototype)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Register(%q, %#v) = %v, want an error containing %q", tt.name, tt.prototype, err, tt.want)
+		}
+	}
+
+	if err := Register("circle", Circle{}); err == nil {
+		t.Error("Register of a name the DefaultRegistry already knows should fail")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustRegister of a duplicate name did not panic")
+		}
+	}()
+	r.MustRegister("disc", Ellipse{})
+}
+
+func TestRegisterPointer(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister("circle", &Circle{})
+
+	if _, err := r.Marshal(Circle{}); err == nil {
+		t.Error("Marshal of a value when only the pointer type is registered should fail")
+	}
+	data, err := r.Marshal(&Circle{Pt(1, 1), 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := r.Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := s.(*Circle); !ok || *c != (Circle{Pt(1, 1), 2}) {
+		t.Errorf("Unmarshal(%s) = %#v, want &Circle{...}", data, s)
+	}
+}
+
+func TestShapesField(t *testing.T) {
+	type drawing struct {
+		Title  string `json:"title"`
+		Shapes Shapes `json:"shapes"`
+	}
+	in := drawing{"house", Shapes{Rect{Pt(0, 0), 4, 3, 0}, Triangle{Pt(0, 3), Pt(4, 3), Pt(2, 5)}}}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"title":"house","shapes":[` +
+		`{"type":"rect","origin":{"x":0,"y":0},"width":4,"height":3},` +
+		`{"type":"triangle","a":{"x":0,"y":3},"b":{"x":4,"y":3},"c":{"x":2,"y":5}}]}`
+	if string(data) != want {
+		t.Errorf("json.Marshal = %s, want %s", data, want)
+	}
+
+	var out drawing
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("json.Unmarshal = %#v, want %#v", out, in)
+	}
+
+	err = json.Unmarshal([]byte(`{"shapes":[{"type":"blob"}]}`), &out)
+	if !errors.Is(err, ErrUnknownType) {
+		t.Errorf("json.Unmarshal with an unknown shape: %v", err)
+	}
+}
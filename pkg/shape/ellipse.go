@@ -0,0 +1,104 @@
+package shape
+
+import "math"
+
+// Circle is the set of points at most Radius away from Center
+type Circle struct {
+	Center Point   `json:"center"`
+	Radius float64 `json:"radius"`
+}
+
+// Area returns "πr²"
+func (c Circle) Area() float64 {
+	return math.Pi * c.Radius * c.Radius
+}
+
+// Perimeter returns "2πr"
+func (c Circle) Perimeter() float64 {
+	return 2 * math.Pi * c.Radius
+}
+
+// Bounds returns the square around the circle
+func (c Circle) Bounds() Box {
+	r := Point{c.Radius, c.Radius}
+	return Box{c.Center.Sub(r), c.Center.Add(r)}
+}
+
+// Contains reports whether "p" is inside the circle or on its outline
+func (c Circle) Contains(p Point) bool {
+	return c.Center.Dist(p) <= c.Radius+Epsilon
+}
+
+// Translate returns the circle moved by "d"
+func (c Circle) Translate(d Point) Shape {
+	return Circle{c.Center.Add(d), c.Radius}
+}
+
+// Scale returns the circle scaled by "f" relative to the origin
+func (c Circle) Scale(f float64) Shape {
+	return Circle{c.Center.Mul(f), c.Radius * f}
+}
+
+// Rotate returns the circle rotated by "theta" around the origin
+func (c Circle) Rotate(theta float64) Shape {
+	return Circle{c.Center.Rotate(theta), c.Radius}
+}
+
+// Ellipse has semi-axes RX and RY, along the X and Y axes before being rotated
+// by Angle around its Center
+type Ellipse struct {
+	Center Point   `json:"center"`
+	RX     float64 `json:"rx"`
+	RY     float64 `json:"ry"`
+	Angle  float64 `json:"angle,omitempty"`
+}
+
+// Area returns "π·rx·ry"
+func (e Ellipse) Area() float64 {
+	return math.Pi * e.RX * e.RY
+}
+
+// Perimeter has no closed form, it uses Ramanujan's second approximation
+// which is exact for circles and within 0.04% for any other ellipse
+func (e Ellipse) Perimeter() float64 {
+	a, b := e.RX, e.RY
+	if a+b == 0 {
+		return 0
+	}
+	h := (a - b) * (a - b) / ((a + b) * (a + b))
+	return math.Pi * (a + b) * (1 + 3*h/(10+math.Sqrt(4-3*h)))
+}
+
+// Bounds returns the box around the rotated ellipse
+func (e Ellipse) Bounds() Box {
+	sin, cos := math.Sincos(e.Angle)
+	w := math.Hypot(e.RX*cos, e.RY*sin)
+	h := math.Hypot(e.RX*sin, e.RY*cos)
+	return Box{e.Center.Sub(Point{w, h}), e.Center.Add(Point{w, h})}
+}
+
+// Contains reports whether "p" is inside the ellipse or on its outline
+func (e Ellipse) Contains(p Point) bool {
+	if e.RX == 0 || e.RY == 0 {
+		return false
+	}
+
+	// Bring the point in the frame where the ellipse is centered and axis-aligned
+	q := p.Sub(e.Center).Rotate(-e.Angle)
+	return (q.X*q.X)/(e.RX*e.RX)+(q.Y*q.Y)/(e.RY*e.RY) <= 1+Epsilon
+}
+
+// Translate returns the ellipse moved by "d"
+func (e Ellipse) Translate(d Point) Shape {
+	return Ellipse{e.Center.Add(d), e.RX, e.RY, e.Angle}
+}
+
+// Scale returns the ellipse scaled by "f" relative to the origin
+func (e Ellipse) Scale(f float64) Shape {
+	return Ellipse{e.Center.Mul(f), e.RX * f, e.RY * f, e.Angle}
+}
+
+// Rotate returns the ellipse rotated by "theta" around the origin
+func (e Ellipse) Rotate(theta float64) Shape {
+	return Ellipse{e.Center.Rotate(theta), e.RX, e.RY, e.Angle + theta}
+}
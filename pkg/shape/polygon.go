@@ -0,0 +1,305 @@
+package shape
+
+import "math"
+
+// Polygon is a closed, simple (non self-intersecting) polygon.
+// The last point connects back to the first one, it must not be repeated
+type Polygon struct {
+	Points []Point `json:"points"`
+}
+
+// Poly returns the polygon with the given vertices
+func Poly(points ...Point) Polygon {
+	return Polygon{points}
+}
+
+// SignedArea returns the area given by the shoelace formula,
+// positive when the points go counterclockwise and negative otherwise
+func (p Polygon) SignedArea() float64 {
+	n := len(p.Points)
+	if n < 3 {
+		return 0
+	}
+
+	// Sum the cross products of consecutive vertices, the "laces" of the shoe
+	var sum float64
+	for i, a := range p.Points {
+		b := p.Points[(i+1)%n]
+		sum += a.X*b.Y - b.X*a.Y
+	}
+	return sum / 2
+}
+
+// Area returns the area of the polygon whatever the orientation of its points
+func (p Polygon) Area() float64 {
+	return math.Abs(p.SignedArea())
+}
+
+// Perimeter returns the total length of the edges
+func (p Polygon) Perimeter() float64 {
+	n := len(p.Points)
+	if n < 2 {
+		return 0
+	}
+
+	var sum float64
+	for i, a := range p.Points {
+		sum += a.Dist(p.Points[(i+1)%n])
+	}
+	return sum
+}
+
+// Centroid returns the center of mass of the polygon,
+// or the average of its points when it has no area
+func (p Polygon) Centroid() Point {
+	a := p.SignedArea()
+	if a == 0 {
+		var c Point
+		for _, q := range p.Points {
+			c = c.Add(q)
+		}
+		return c.Mul(1 / float64(max(len(p.Points), 1)))
+	}
+
+	var c Point
+	n := len(p.Points)
+	for i, q := range p.Points {
+		r := p.Points[(i+1)%n]
+		cross := q.X*r.Y - r.X*q.Y
+		c = c.Add(q.Add(r).Mul(cross))
+	}
+	return c.Mul(1 / (6 * a))
+}
+
+// Bounds returns the box around the vertices
+func (p Polygon) Bounds() Box {
+	return BoxOf(p.Points...)
+}
+
+// Contains reports whether "q" is inside the polygon or on one of its edges.
+// Points strictly inside are found by casting a ray to the right of "q"
+// and counting how many edges it crosses: an odd count means inside
+func (p Polygon) Contains(q Point) bool {
+	n := len(p.Points)
+	inside := false
+	for i, a := range p.Points {
+		b := p.Points[(i+1)%n]
+		if onSegment(q, a, b) {
+			return true
+		}
+		if (a.Y > q.Y) != (b.Y > q.Y) {
+			x := a.X + (q.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+			if q.X < x {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// Translate returns the polygon moved by "d"
+func (p Polygon) Translate(d Point) Shape {
+	return p.mapPoints(func(q Point) Point { return q.Add(d) })
+}
+
+// Scale returns the polygon scaled by "f" relative to the origin
+func (p Polygon) Scale(f float64) Shape {
+	return p.mapPoints(func(q Point) Point { return q.Mul(f) })
+}
+
+// Rotate returns the polygon rotated by "theta" around the origin
+func (p Polygon) Rotate(theta float64) Shape {
+	return p.mapPoints(func(q Point) Point { return q.Rotate(theta) })
+}
+
+func (p Polygon) mapPoints(f func(Point) Point) Polygon {
+	points := make([]Point, len(p.Points))
+	for i, q := range p.Points {
+		points[i] = f(q)
+	}
+	return Polygon{points}
+}
+
+// onSegment reports whether "q" lies on the segment from "a" to "b"
+func onSegment(q, a, b Point) bool {
+	ab, aq := b.Sub(a), q.Sub(a)
+	length := math.Hypot(ab.X, ab.Y)
+	if length == 0 {
+		return a.Dist(q) <= Epsilon
+	}
+
+	// The distance from the line, then the position along it
+	if math.Abs(ab.X*aq.Y-ab.Y*aq.X)/length > Epsilon {
+		return false
+	}
+	t := (ab.X*aq.X + ab.Y*aq.Y) / (length * length)
+	return -Epsilon <= t && t <= 1+Epsilon
+}
+
+// Triangle is the polygon with vertices A, B and C
+type Triangle struct {
+	A Point `json:"a"`
+	B Point `json:"b"`
+	C Point `json:"c"`
+}
+
+// Polygon returns the triangle as a general polygon
+func (t Triangle) Polygon() Polygon {
+	return Polygon{[]Point{t.A, t.B, t.C}}
+}
+
+// Area returns half the absolute cross product of two edges
+func (t Triangle) Area() float64 {
+	ab, ac := t.B.Sub(t.A), t.C.Sub(t.A)
+	return math.Abs(ab.X*ac.Y-ab.Y*ac.X) / 2
+}
+
+// Perimeter returns the sum of the lengths of the three edges
+func (t Triangle) Perimeter() float64 {
+	return t.A.Dist(t.B) + t.B.Dist(t.C) + t.C.Dist(t.A)
+}
+
+// Bounds returns the box around the vertices
+func (t Triangle) Bounds() Box {
+	return BoxOf(t.A, t.B, t.C)
+}
+
+// Contains reports whether "p" is inside the triangle or on one of its edges
+func (t Triangle) Contains(p Point) bool {
+	return t.Polygon().Contains(p)
+}
+
+// Translate returns the triangle moved by "d"
+func (t Triangle) Translate(d Point) Shape {
+	return Triangle{t.A.Add(d), t.B.Add(d), t.C.Add(d)}
+}
+
+// Scale returns the triangle scaled by "f" relative to the origin
+func (t Triangle) Scale(f float64) Shape {
+	return Triangle{t.A.Mul(f), t.B.Mul(f), t.C.Mul(f)}
+}
+
+// Rotate returns the triangle rotated by "theta" around the origin
+func (t Triangle) Rotate(theta float64) Shape {
+	return Triangle{t.A.Rotate(theta), t.B.Rotate(theta), t.C.Rotate(theta)}
+}
+
+// Rect is a Width by Height rectangle whose first corner is at Origin,
+// rotated by Angle around that corner
+type Rect struct {
+	Origin Point   `json:"origin"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	Angle  float64 `json:"angle,omitempty"`
+}
+
+// Polygon returns the four corners of the rectangle, counterclockwise from Origin
+func (r Rect) Polygon() Polygon {
+	corner := func(x, y float64) Point {
+		return r.Origin.Add(Point{x, y}.Rotate(r.Angle))
+	}
+	return Polygon{[]Point{corner(0, 0), corner(r.Width, 0), corner(r.Width, r.Height), corner(0, r.Height)}}
+}
+
+// Area returns "width × height"
+func (r Rect) Area() float64 {
+	return r.Width * r.Height
+}
+
+// Perimeter returns "2 × (width + height)"
+func (r Rect) Perimeter() float64 {
+	return 2*r.Width + 2*r.Height
+}
+
+// Bounds returns the box around the rotated rectangle
+func (r Rect) Bounds() Box {
+	return r.Polygon().Bounds()
+}
+
+// Contains reports whether "p" is inside the rectangle or on one of its edges
+func (r Rect) Contains(p Point) bool {
+	q := p.Sub(r.Origin).Rotate(-r.Angle)
+	return -Epsilon <= q.X && q.X <= r.Width+Epsilon &&
+		-Epsilon <= q.Y && q.Y <= r.Height+Epsilon
+}
+
+// Translate returns the rectangle moved by "d"
+func (r Rect) Translate(d Point) Shape {
+	return Rect{r.Origin.Add(d), r.Width, r.Height, r.Angle}
+}
+
+// Scale returns the rectangle scaled by "f" relative to the origin
+func (r Rect) Scale(f float64) Shape {
+	return Rect{r.Origin.Mul(f), r.Width * f, r.Height * f, r.Angle}
+}
+
+// Rotate returns the rectangle rotated by "theta" around the origin
+func (r Rect) Rotate(theta float64) Shape {
+	return Rect{r.Origin.Rotate(theta), r.Width, r.Height, r.Angle + theta}
+}
+
+// RegularPolygon has Sides equal edges, with its vertices on a circle
+// of the given Radius around Center, the first one at Angle
+type RegularPolygon struct {
+	Center Point   `json:"center"`
+	Sides  int     `json:"sides"`
+	Radius float64 `json:"radius"`
+	Angle  float64 `json:"angle,omitempty"`
+}
+
+// Polygon returns the vertices of the regular polygon, counterclockwise
+func (r RegularPolygon) Polygon() Polygon {
+	if r.Sides < 3 {
+		return Polygon{}
+	}
+
+	points := make([]Point, r.Sides)
+	for i := range points {
+		theta := r.Angle + 2*math.Pi*float64(i)/float64(r.Sides)
+		points[i] = r.Center.Add(Point{r.Radius, 0}.Rotate(theta))
+	}
+	return Polygon{points}
+}
+
+// Area returns "n·r²·sin(2π/n)/2", n isosceles triangles meeting at the center
+func (r RegularPolygon) Area() float64 {
+	if r.Sides < 3 {
+		return 0
+	}
+	n := float64(r.Sides)
+	return n * r.Radius * r.Radius * math.Sin(2*math.Pi/n) / 2
+}
+
+// Perimeter returns "2·n·r·sin(π/n)"
+func (r RegularPolygon) Perimeter() float64 {
+	if r.Sides < 3 {
+		return 0
+	}
+	n := float64(r.Sides)
+	return 2 * n * r.Radius * math.Sin(math.Pi/n)
+}
+
+// Bounds returns the box around the vertices
+func (r RegularPolygon) Bounds() Box {
+	return r.Polygon().Bounds()
+}
+
+// Contains reports whether "p" is inside the regular polygon or on one of its edges
+func (r RegularPolygon) Contains(p Point) bool {
+	return r.Polygon().Contains(p)
+}
+
+// Translate returns the regular polygon moved by "d"
+func (r RegularPolygon) Translate(d Point) Shape {
+	return RegularPolygon{r.Center.Add(d), r.Sides, r.Radius, r.Angle}
+}
+
+// Scale returns the regular polygon scaled by "f" relative to the origin
+func (r RegularPolygon) Scale(f float64) Shape {
+	return RegularPolygon{r.Center.Mul(f), r.Sides, r.Radius * f, r.Angle}
+}
+
+// Rotate returns the regular polygon rotated by "theta" around the origin
+func (r RegularPolygon) Rotate(theta float64) Shape {
+	return RegularPolygon{r.Center.Rotate(theta), r.Sides, r.Radius, r.Angle + theta}
+}
@@ -0,0 +1,146 @@
+// Package shape is an exported, fuller version of the "geometry" interface
+// from the interfaces example: besides area and perimeter,
+// every shape knows its bounding box, whether it contains a point,
+// and how to move, scale and rotate itself.
+//
+// Transformations never modify a shape, they return a new one.
+// Scaling and rotation are relative to the origin of the plane,
+// combine them with Translate to work around another point.
+// Angles are in radians, counterclockwise.
+package shape
+
+import "math"
+
+// Shape is a closed figure in the plane
+type Shape interface {
+	// Area returns the surface enclosed by the shape
+	Area() float64
+
+	// Perimeter returns the length of the outline of the shape
+	Perimeter() float64
+
+	// Bounds returns the smallest axis-aligned box around the shape
+	Bounds() Box
+
+	// Contains reports whether "p" is inside the shape or on its outline
+	Contains(p Point) bool
+
+	// Translate returns the shape moved by "d"
+	Translate(d Point) Shape
+
+	// Scale returns the shape scaled by "f" relative to the origin, "f" must be positive
+	Scale(f float64) Shape
+
+	// Rotate returns the shape rotated by "theta" around the origin
+	Rotate(theta float64) Shape
+}
+
+// Epsilon is the tolerance used when deciding whether a point lies on an outline
+const Epsilon = 1e-9
+
+// Point is a location in the plane, or a vector between two locations
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Pt is shorthand for Point{x, y}
+func Pt(x, y float64) Point {
+	return Point{x, y}
+}
+
+// Add returns the vector sum "p+q"
+func (p Point) Add(q Point) Point {
+	return Point{p.X + q.X, p.Y + q.Y}
+}
+
+// Sub returns the vector difference "p-q"
+func (p Point) Sub(q Point) Point {
+	return Point{p.X - q.X, p.Y - q.Y}
+}
+
+// Mul returns "p" scaled by "f"
+func (p Point) Mul(f float64) Point {
+	return Point{p.X * f, p.Y * f}
+}
+
+// Rotate returns "p" rotated by "theta" around the origin
+func (p Point) Rotate(theta float64) Point {
+	sin, cos := math.Sincos(theta)
+	return Point{p.X*cos - p.Y*sin, p.X*sin + p.Y*cos}
+}
+
+// Dist returns the distance between "p" and "q"
+func (p Point) Dist(q Point) float64 {
+	return math.Hypot(p.X-q.X, p.Y-q.Y)
+}
+
+// Box is an axis-aligned rectangle given by its lower-left and upper-right corners
+type Box struct {
+	Min Point `json:"min"`
+	Max Point `json:"max"`
+}
+
+// BoxOf returns the smallest box containing all the points
+func BoxOf(points ...Point) Box {
+	if len(points) == 0 {
+		return Box{}
+	}
+
+	b := Box{points[0], points[0]}
+	for _, p := range points[1:] {
+		b.Min.X = math.Min(b.Min.X, p.X)
+		b.Min.Y = math.Min(b.Min.Y, p.Y)
+		b.Max.X = math.Max(b.Max.X, p.X)
+		b.Max.Y = math.Max(b.Max.Y, p.Y)
+	}
+	return b
+}
+
+// Width returns the horizontal size of the box
+func (b Box) Width() float64 {
+	return b.Max.X - b.Min.X
+}
+
+// Height returns the vertical size of the box
+func (b Box) Height() float64 {
+	return b.Max.Y - b.Min.Y
+}
+
+// Center returns the middle of the box
+func (b Box) Center() Point {
+	return b.Min.Add(b.Max).Mul(0.5)
+}
+
+// Contains reports whether "p" is inside the box or on its edges
+func (b Box) Contains(p Point) bool {
+	return b.Min.X-Epsilon <= p.X && p.X <= b.Max.X+Epsilon &&
+		b.Min.Y-Epsilon <= p.Y && p.Y <= b.Max.Y+Epsilon
+}
+
+// Union returns the smallest box containing both "b" and "c"
+func (b Box) Union(c Box) Box {
+	return BoxOf(b.Min, b.Max, c.Min, c.Max)
+}
+
+// Bounds returns the smallest box containing every shape, or an empty box when there are none
+func Bounds(shapes ...Shape) Box {
+	if len(shapes) == 0 {
+		return Box{}
+	}
+
+	b := shapes[0].Bounds()
+	for _, s := range shapes[1:] {
+		b = b.Union(s.Bounds())
+	}
+	return b
+}
+
+var (
+	_ Shape = Circle{}
+	_ Shape = Ellipse{}
+	_ Shape = Polygon{}
+	_ Shape = Triangle{}
+	_ Shape = Rect{}
+	_ Shape = RegularPolygon{}
+)
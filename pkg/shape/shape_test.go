@@ -0,0 +1,188 @@
+package shape
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+var (
+	sqrt2 = math.Sqrt2
+	sqrt3 = math.Sqrt(3)
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) <= Epsilon
+}
+
+func nearPoint(p, q Point) bool {
+	return near(p.X, q.X) && near(p.Y, q.Y)
+}
+
+func nearBox(a, b Box) bool {
+	return nearPoint(a.Min, b.Min) && nearPoint(a.Max, b.Max)
+}
+
+var shapeTests = []struct {
+	name      string
+	shape     Shape
+	area      float64
+	perimeter float64
+	bounds    Box
+}{
+	{"circle", Circle{Pt(1, 1), 2}, 4 * math.Pi, 4 * math.Pi, Box{Pt(-1, -1), Pt(3, 3)}},
+	{"round ellipse", Ellipse{Pt(0, 0), 3, 3, 0}, 9 * math.Pi, 6 * math.Pi, Box{Pt(-3, -3), Pt(3, 3)}},
+	{"rotated ellipse", Ellipse{Pt(0, 0), 2, 1, math.Pi / 2}, 2 * math.Pi, 9.688448216130085, Box{Pt(-1, -2), Pt(1, 2)}},
+	{"triangle", Triangle{Pt(0, 0), Pt(4, 0), Pt(0, 3)}, 6, 12, Box{Pt(0, 0), Pt(4, 3)}},
+	{"clockwise polygon", Poly(Pt(0, 0), Pt(0, 2), Pt(2, 2), Pt(2, 0)), 4, 8, Box{Pt(0, 0), Pt(2, 2)}},
+	{"L polygon", Poly(Pt(0, 0), Pt(2, 0), Pt(2, 1), Pt(1, 1), Pt(1, 2), Pt(0, 2)), 3, 8, Box{Pt(0, 0), Pt(2, 2)}},
+	{"rect", Rect{Pt(1, 2), 4, 2, 0}, 8, 12, Box{Pt(1, 2), Pt(5, 4)}},
+	{"rect quarter turn", Rect{Pt(1, 0), 4, 2, math.Pi / 2}, 8, 12, Box{Pt(-1, 0), Pt(1, 4)}},
+	{"rect eighth turn", Rect{Pt(0, 0), 1, 1, math.Pi / 4}, 1, 4, Box{Pt(-sqrt2/2, 0), Pt(sqrt2/2, sqrt2)}},
+	{"square as regular polygon", RegularPolygon{Pt(0, 0), 4, sqrt2, math.Pi / 4}, 4, 8, Box{Pt(-1, -1), Pt(1, 1)}},
+	{"hexagon", RegularPolygon{Pt(0, 0), 6, 1, 0}, 3 * sqrt3 / 2, 6, Box{Pt(-1, -sqrt3/2), Pt(1, sqrt3/2)}},
+	{"rotated hexagon", RegularPolygon{Pt(2, 0), 6, 1, math.Pi / 6}, 3 * sqrt3 / 2, 6, Box{Pt(2-sqrt3/2, -1), Pt(2+sqrt3/2, 1)}},
+	{"degenerate regular polygon", RegularPolygon{Pt(0, 0), 2, 1, 0}, 0, 0, Box{}},
+}
+
+func TestArea(t *testing.T) {
+	for _, tt := range shapeTests {
+		if got := tt.shape.Area(); !near(got, tt.area) {
+			t.Errorf("%s: Area() = %v, want %v", tt.name, got, tt.area)
+		}
+	}
+}
+
+func TestPerimeter(t *testing.T) {
+	for _, tt := range shapeTests {
+		if got := tt.shape.Perimeter(); !near(got, tt.perimeter) {
+			t.Errorf("%s: Perimeter() = %v, want %v", tt.name, got, tt.perimeter)
+		}
+	}
+
+	// Ramanujan's approximation is within 0.04% of the exact perimeter of any ellipse
+	exact := 9.688448220547675
+	if got := (Ellipse{RX: 2, RY: 1}).Perimeter(); math.Abs(got-exact)/exact > 4e-4 {
+		t.Errorf("ellipse perimeter %v too far from %v", got, exact)
+	}
+}
+
+func TestBounds(t *testing.T) {
+	for _, tt := range shapeTests {
+		if got := tt.shape.Bounds(); !nearBox(got, tt.bounds) {
+			t.Errorf("%s: Bounds() = %v, want %v", tt.name, got, tt.bounds)
+		}
+	}
+
+	b := Bounds(Circle{Pt(0, 0), 1}, Rect{Pt(2, 2), 1, 1, 0})
+	if !nearBox(b, Box{Pt(-1, -1), Pt(3, 3)}) {
+		t.Errorf("Bounds of two shapes = %v", b)
+	}
+}
+
+func TestCentroid(t *testing.T) {
+	tests := []struct {
+		name    string
+		polygon Polygon
+		want    Point
+	}{
+		{"square", Poly(Pt(0, 0), Pt(2, 0), Pt(2, 2), Pt(0, 2)), Pt(1, 1)},
+		{"clockwise square", Poly(Pt(0, 0), Pt(0, 2), Pt(2, 2), Pt(2, 0)), Pt(1, 1)},
+		{"triangle", Triangle{Pt(0, 0), Pt(4, 0), Pt(0, 3)}.Polygon(), Pt(4.0/3, 1)},
+		{"L shape", Poly(Pt(0, 0), Pt(2, 0), Pt(2, 1), Pt(1, 1), Pt(1, 2), Pt(0, 2)), Pt(5.0/6, 5.0/6)},
+		{"rotated rect", Rect{Pt(1, 0), 4, 2, math.Pi / 2}.Polygon(), Pt(0, 2)},
+		{"hexagon", RegularPolygon{Pt(3, -1), 6, 2, 0.3}.Polygon(), Pt(3, -1)},
+		{"collinear", Poly(Pt(0, 0), Pt(1, 1), Pt(2, 2)), Pt(1, 1)},
+		{"empty", Poly(), Pt(0, 0)},
+	}
+	for _, tt := range tests {
+		if got := tt.polygon.Centroid(); !nearPoint(got, tt.want) {
+			t.Errorf("%s: Centroid() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		shape Shape
+		p     Point
+		want  bool
+	}{
+		{Circle{Pt(0, 0), 1}, Pt(0, 0), true},
+		{Circle{Pt(0, 0), 1}, Pt(1, 0), true},
+		{Circle{Pt(0, 0), 1}, Pt(0.8, 0.7), false},
+
+		{Ellipse{Pt(0, 0), 2, 1, math.Pi / 2}, Pt(0, 2), true},
+		{Ellipse{Pt(0, 0), 2, 1, math.Pi / 2}, Pt(2, 0), false},
+		{Ellipse{Pt(0, 0), 0, 1, 0}, Pt(0, 0), false},
+
+		{Triangle{Pt(0, 0), Pt(4, 0), Pt(0, 3)}, Pt(1, 1), true},
+		{Triangle{Pt(0, 0), Pt(4, 0), Pt(0, 3)}, Pt(2, 1.5), true}, // on the hypotenuse
+		{Triangle{Pt(0, 0), Pt(4, 0), Pt(0, 3)}, Pt(2, 1.6), false},
+		{Triangle{Pt(0, 0), Pt(4, 0), Pt(0, 3)}, Pt(4, 0), true}, // a vertex
+
+		// The ray cast from inside the notch of the L goes through a vertex
+		{Poly(Pt(0, 0), Pt(2, 0), Pt(2, 1), Pt(1, 1), Pt(1, 2), Pt(0, 2)), Pt(1.5, 1.5), false},
+		{Poly(Pt(0, 0), Pt(2, 0), Pt(2, 1), Pt(1, 1), Pt(1, 2), Pt(0, 2)), Pt(0.5, 1), true},
+		{Poly(Pt(0, 0), Pt(2, 0), Pt(2, 1), Pt(1, 1), Pt(1, 2), Pt(0, 2)), Pt(1.5, 1), true}, // on an edge
+
+		{Rect{Pt(1, 0), 4, 2, math.Pi / 2}, Pt(0, 2), true},
+		{Rect{Pt(1, 0), 4, 2, math.Pi / 2}, Pt(1, 2), true},  // on an edge
+		{Rect{Pt(1, 0), 4, 2, math.Pi / 2}, Pt(-1, 4), true}, // a corner
+		{Rect{Pt(1, 0), 4, 2, math.Pi / 2}, Pt(1.001, 2), false},
+		{Rect{Pt(1, 0), 4, 2, math.Pi / 2}, Pt(3, 1), false}, // inside the unrotated rect only
+		{Rect{Pt(0, 0), 1, 1, math.Pi / 4}, Pt(0, sqrt2), true},
+		{Rect{Pt(0, 0), 1, 1, math.Pi / 4}, Pt(0.6, 0.1), false},
+
+		{RegularPolygon{Pt(0, 0), 4, sqrt2, math.Pi / 4}, Pt(1, 0), true}, // on an edge
+		{RegularPolygon{Pt(0, 0), 4, sqrt2, math.Pi / 4}, Pt(1.001, 0), false},
+		{RegularPolygon{Pt(0, 0), 6, 1, 0}, Pt(0, sqrt3/2), true}, // on an edge
+		{RegularPolygon{Pt(0, 0), 6, 1, 0}, Pt(0.9, 0.5), false},
+	}
+	for _, tt := range tests {
+		if got := tt.shape.Contains(tt.p); got != tt.want {
+			t.Errorf("%#v.Contains(%v) = %v, want %v", tt.shape, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestTransform(t *testing.T) {
+	for _, tt := range shapeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.area == 0 {
+				t.Skip("a degenerate shape has no bounds to move")
+			}
+			moved := tt.shape.Translate(Pt(3, -2))
+			if !nearBox(moved.Bounds(), Box{tt.bounds.Min.Add(Pt(3, -2)), tt.bounds.Max.Add(Pt(3, -2))}) {
+				t.Errorf("Translate: bounds %v", moved.Bounds())
+			}
+
+			scaled := tt.shape.Scale(2)
+			if !near(scaled.Area(), 4*tt.area) || !near(scaled.Perimeter(), 2*tt.perimeter) {
+				t.Errorf("Scale: area %v, perimeter %v", scaled.Area(), scaled.Perimeter())
+			}
+
+			// A full turn in four steps comes back to the same shape
+			turned := tt.shape
+			for range 4 {
+				turned = turned.Rotate(math.Pi / 2)
+			}
+			if !near(turned.Area(), tt.area) || !nearBox(turned.Bounds(), tt.bounds) {
+				t.Errorf("Rotate: %v", fmt.Sprint(turned))
+			}
+		})
+	}
+}
+
+func TestBox(t *testing.T) {
+	b := BoxOf(Pt(3, 1), Pt(-1, 4), Pt(0, 0))
+	if !nearBox(b, Box{Pt(-1, 0), Pt(3, 4)}) || b.Width() != 4 || b.Height() != 4 || b.Center() != Pt(1, 2) {
+		t.Errorf("BoxOf = %v", b)
+	}
+	if !b.Contains(Pt(3, 4)) || b.Contains(Pt(3.1, 4)) {
+		t.Error("Box.Contains on the corner")
+	}
+	if BoxOf() != (Box{}) {
+		t.Error("BoxOf() is not empty")
+	}
+}
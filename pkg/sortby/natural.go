@@ -0,0 +1,88 @@
+package sortby
+
+// NaturalCompare compares "a" and "b" in natural (human) order:
+// runs of ASCII digits are compared by their numeric value,
+// so "file2" sorts before "file10", everything else is compared byte by byte.
+//
+// Numbers that are equal in value but differ in leading zeros ("7" and "007")
+// are ordered by the number of leading zeros, fewest first,
+// so that the ordering stays total and only equal strings compare as 0
+func NaturalCompare(a, b string) int {
+	// The first difference in leading zeros, used only when everything else is equal
+	zeros := 0
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		ca, cb := a[i], b[j]
+
+		if !isDigit(ca) || !isDigit(cb) {
+			if ca != cb {
+				if ca < cb {
+					return -1
+				}
+				return 1
+			}
+			i++
+			j++
+			continue
+		}
+
+		// Both sides start a run of digits
+		na, za := scanNumber(a, i)
+		nb, zb := scanNumber(b, j)
+
+		// Skip leading zeros, then a longer run of significant digits is a bigger number
+		da, db := a[i+za:na], b[j+zb:nb]
+		if len(da) != len(db) {
+			if len(da) < len(db) {
+				return -1
+			}
+			return 1
+		}
+		if da != db {
+			if da < db {
+				return -1
+			}
+			return 1
+		}
+		if zeros == 0 && za != zb {
+			if za < zb {
+				zeros = -1
+			} else {
+				zeros = 1
+			}
+		}
+
+		i, j = na, nb
+	}
+
+	switch {
+	case len(a)-i < len(b)-j:
+		return -1
+	case len(a)-i > len(b)-j:
+		return 1
+	}
+	return zeros
+}
+
+// NaturalLess reports whether "a" sorts before "b" in natural order
+func NaturalLess(a, b string) bool {
+	return NaturalCompare(a, b) < 0
+}
+
+// scanNumber returns the end of the run of digits starting at "start"
+// and the number of leading zeros in it, keeping the last digit significant
+func scanNumber(s string, start int) (end, zeros int) {
+	end = start
+	for end < len(s) && isDigit(s[end]) {
+		end++
+	}
+	for start+zeros < end-1 && s[start+zeros] == '0' {
+		zeros++
+	}
+	return end, zeros
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
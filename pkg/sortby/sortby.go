@@ -0,0 +1,88 @@
+// Package sortby builds orderings for slices out of small, chainable comparators,
+// so that sorting by a new key does not require a new type with Len, Less and Swap.
+//
+//	sortby.ThenByKey(sortby.By(func(s string) int { return len(s) }), strings.ToLower).
+//		Desc().
+//		SortStable(words)
+//
+// ThenByKey is a function rather than a method, as methods cannot have type parameters
+// of their own; ThenBy chains a whole Comparator instead
+package sortby
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Comparator reports the relative order of "a" and "b":
+// a negative number when "a" sorts first, a positive number when "b" sorts first,
+// and zero when they are equivalent
+type Comparator[T any] func(a, b T) int
+
+// By returns a Comparator that orders values by the key extracted with "key"
+func By[T any, K cmp.Ordered](key func(T) K) Comparator[T] {
+	return func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// ByFunc returns a Comparator that orders values by the key extracted with "key",
+// comparing keys with "compare" instead of their natural order
+func ByFunc[T, K any](key func(T) K, compare func(a, b K) int) Comparator[T] {
+	return func(a, b T) int {
+		return compare(key(a), key(b))
+	}
+}
+
+// Natural returns a Comparator that orders values by the string extracted with "key"
+// in natural (human) order, see NaturalCompare
+func Natural[T any](key func(T) string) Comparator[T] {
+	return ByFunc(key, NaturalCompare)
+}
+
+// ThenBy returns a Comparator that breaks ties of "c" with "next"
+func (c Comparator[T]) ThenBy(next Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if r := c(a, b); r != 0 {
+			return r
+		}
+		return next(a, b)
+	}
+}
+
+// ThenByKey returns a Comparator that breaks ties of "c" by the key extracted with "key",
+// the same as "c.ThenBy(By(key))"
+func ThenByKey[T any, K cmp.Ordered](c Comparator[T], key func(T) K) Comparator[T] {
+	return c.ThenBy(By(key))
+}
+
+// Desc returns a Comparator that reverses the whole chain built so far,
+// e.g. "By(x).ThenBy(y).Desc()" sorts by "x" and "y" descending,
+// while "By(x).Desc().ThenBy(y)" sorts by "x" descending and "y" ascending
+func (c Comparator[T]) Desc() Comparator[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// Sort sorts "s" in place, equal elements may be reordered
+func (c Comparator[T]) Sort(s []T) {
+	slices.SortFunc(s, c)
+}
+
+// SortStable sorts "s" in place, keeping the original order of equal elements
+func (c Comparator[T]) SortStable(s []T) {
+	slices.SortStableFunc(s, c)
+}
+
+// IsSorted reports whether "s" is already sorted by "c"
+func (c Comparator[T]) IsSorted(s []T) bool {
+	return slices.IsSortedFunc(s, c)
+}
+
+// Less adapts "c" to the "less" function expected by "sort.Slice" and "sort.SliceStable"
+func (c Comparator[T]) Less(s []T) func(i, j int) bool {
+	return func(i, j int) bool {
+		return c(s[i], s[j]) < 0
+	}
+}
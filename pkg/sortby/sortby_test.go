@@ -0,0 +1,128 @@
+package sortby
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+type record struct {
+	Group uint8
+	Name  string
+	Index int
+}
+
+// records turns random input into records with few distinct keys, so ties are common
+func records(groups []uint8, names []string) []record {
+	rs := make([]record, min(len(groups), len(names)))
+	for i := range rs {
+		rs[i] = record{Group: groups[i] % 4, Name: strings.ToLower(names[i][:min(len(names[i]), 1)]), Index: i}
+	}
+	return rs
+}
+
+func TestSortStableMatchesSliceStable(t *testing.T) {
+	comparators := map[string]Comparator[record]{
+		"by group":           By(func(r record) uint8 { return r.Group }),
+		"by group then name": ThenByKey(By(func(r record) uint8 { return r.Group }), func(r record) string { return r.Name }),
+		"desc":               By(func(r record) uint8 { return r.Group }).Desc(),
+		"desc then name":     By(func(r record) uint8 { return r.Group }).Desc().ThenBy(Natural(func(r record) string { return r.Name })),
+	}
+
+	for name, c := range comparators {
+		t.Run(name, func(t *testing.T) {
+			check := func(groups []uint8, names []string) bool {
+				got := records(groups, names)
+				want := slices.Clone(got)
+
+				c.SortStable(got)
+				sort.SliceStable(want, c.Less(want))
+				return slices.Equal(got, want) && c.IsSorted(got)
+			}
+			if err := quick.Check(check, &quick.Config{MaxCount: 500}); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestThenByKey(t *testing.T) {
+	words := []string{"pear", "Fig", "apple", "kiwi", "fig", "Date", "banana"}
+	ThenByKey(By(func(s string) int { return len(s) }), strings.ToLower).SortStable(words)
+	want := []string{"Fig", "fig", "Date", "kiwi", "pear", "apple", "banana"}
+	if !slices.Equal(words, want) {
+		t.Errorf("by length then lower case = %q, want %q", words, want)
+	}
+
+	ThenByKey(By(func(s string) int { return len(s) }), strings.ToLower).Desc().SortStable(words)
+	want = []string{"banana", "apple", "pear", "kiwi", "Date", "Fig", "fig"}
+	if !slices.Equal(words, want) {
+		t.Errorf("descending = %q, want %q", words, want)
+	}
+}
+
+func TestSortIsOrdered(t *testing.T) {
+	c := By(func(r record) uint8 { return r.Group }).ThenBy(By(func(r record) int { return r.Index }).Desc())
+	check := func(groups []uint8, names []string) bool {
+		rs := records(groups, names)
+		c.Sort(rs)
+		return c.IsSorted(rs)
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNaturalCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"file2", "file10", -1},
+		{"file10", "file2", 1},
+		{"file10", "file10", 0},
+		{"file", "file1", -1},
+		{"a1b2", "a1b10", -1},
+		{"2", "10", -1},
+		{"x99", "x100", -1},
+
+		// Leading zeros only break ties between equal numbers, fewest first
+		{"7", "007", -1},
+		{"007", "7", 1},
+		{"file007", "file8", -1},
+		{"file010", "file9", 1},
+		{"a01b", "a1c", -1},
+		{"0", "00", -1},
+
+		// Other characters are compared byte by byte, upper case first
+		{"File2", "file1", -1},
+		{"file2", "File10", 1},
+		{"ABC", "abc", -1},
+		{"abc", "abd", -1},
+		{"", "", 0},
+		{"", "a", -1},
+	}
+	for _, tt := range tests {
+		if got := NaturalCompare(tt.a, tt.b); got != tt.want {
+			t.Errorf("NaturalCompare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := NaturalCompare(tt.b, tt.a); got != -tt.want {
+			t.Errorf("NaturalCompare(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+		if got := NaturalLess(tt.a, tt.b); got != (tt.want < 0) {
+			t.Errorf("NaturalLess(%q, %q) = %v", tt.a, tt.b, got)
+		}
+	}
+}
+
+func TestNaturalSort(t *testing.T) {
+	files := []string{"file10.txt", "file2.txt", "file1.txt", "file02.txt", "File3.txt"}
+	Natural(func(s string) string { return s }).SortStable(files)
+
+	want := []string{"File3.txt", "file1.txt", "file2.txt", "file02.txt", "file10.txt"}
+	if !slices.Equal(files, want) {
+		t.Errorf("got %q, want %q", files, want)
+	}
+}
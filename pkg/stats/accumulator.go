@@ -0,0 +1,98 @@
+package stats
+
+import "math"
+
+// Accumulator computes the count, mean, variance, minimum and maximum of a stream
+// in constant memory, with Welford's algorithm which stays accurate
+// where summing squares would lose precision.
+// The zero value is an empty accumulator ready to use
+type Accumulator struct {
+	n        int
+	mean, m2 float64 // m2 is the sum of squared distances to the mean
+	min, max float64
+}
+
+// Add adds "x" to the stream
+func (a *Accumulator) Add(x float64) {
+	a.n++
+	if a.n == 1 {
+		a.min, a.max = x, x
+	} else {
+		a.min = math.Min(a.min, x)
+		a.max = math.Max(a.max, x)
+	}
+
+	delta := x - a.mean
+	a.mean += delta / float64(a.n)
+	a.m2 += delta * (x - a.mean)
+}
+
+// Merge adds everything "b" has seen, as if its values had been added to "a",
+// so that partial results computed in parallel can be combined
+func (a *Accumulator) Merge(b Accumulator) {
+	if b.n == 0 {
+		return
+	}
+	if a.n == 0 {
+		*a = b
+		return
+	}
+
+	n := a.n + b.n
+	delta := b.mean - a.mean
+	a.m2 += b.m2 + delta*delta*float64(a.n)*float64(b.n)/float64(n)
+	a.mean += delta * float64(b.n) / float64(n)
+	a.n = n
+	a.min = math.Min(a.min, b.min)
+	a.max = math.Max(a.max, b.max)
+}
+
+// Count returns the number of values added
+func (a *Accumulator) Count() int {
+	return a.n
+}
+
+// Mean returns the mean of the values, NaN when there are none
+func (a *Accumulator) Mean() float64 {
+	if a.n == 0 {
+		return math.NaN()
+	}
+	return a.mean
+}
+
+// Variance returns the population variance, NaN when there are no values
+func (a *Accumulator) Variance() float64 {
+	if a.n == 0 {
+		return math.NaN()
+	}
+	return a.m2 / float64(a.n)
+}
+
+// SampleVariance returns the sample variance, NaN with fewer than two values
+func (a *Accumulator) SampleVariance() float64 {
+	if a.n < 2 {
+		return math.NaN()
+	}
+	return a.m2 / float64(a.n-1)
+}
+
+// StdDev returns the population standard deviation
+func (a *Accumulator) StdDev() float64 {
+	return math.Sqrt(a.Variance())
+}
+
+// Min returns the smallest value, NaN when there are none
+func (a *Accumulator) Min() float64 {
+	if a.n == 0 {
+		return math.NaN()
+	}
+	return a.min
+}
+
+// Max returns the largest value, NaN when there are none
+func (a *Accumulator) Max() float64 {
+	if a.n == 0 {
+		return math.NaN()
+	}
+	return a.max
+}
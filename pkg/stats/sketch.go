@@ -0,0 +1,117 @@
+package stats
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// DefaultSketchSize is the buffer size used by NewSketch for sizes below one
+const DefaultSketchSize = 200
+
+// Sketch estimates quantiles of a stream in memory that grows only
+// logarithmically with the number of values, following the idea of the KLL sketch.
+//
+// Values go into a buffer of "k" elements at level 0. When a level is full
+// it is sorted and every other value, starting at a random offset, moves up one level
+// where it stands for twice as many values; the others are dropped.
+// The rank error of Quantile shrinks as "k" grows, about 1% for the default size
+type Sketch struct {
+	k      int
+	levels [][]float64
+	n      int
+	rng    *rand.Rand
+}
+
+// NewSketch returns an empty sketch with buffers of "k" values.
+// The random choices are seeded, so the same stream always gives the same estimates
+func NewSketch(k int) *Sketch {
+	if k < 2 {
+		k = DefaultSketchSize
+	}
+	return &Sketch{k: k, levels: [][]float64{nil}, rng: rand.New(rand.NewPCG(1, 2))}
+}
+
+// Add adds "x" to the stream
+func (s *Sketch) Add(x float64) {
+	s.n++
+	s.levels[0] = append(s.levels[0], x)
+	s.compact(0)
+}
+
+// Count returns the number of values added
+func (s *Sketch) Count() int {
+	return s.n
+}
+
+// compact halves every full level starting at "level"
+func (s *Sketch) compact(level int) {
+	for ; level < len(s.levels) && len(s.levels[level]) >= s.k; level++ {
+		buf := s.levels[level]
+		slices.Sort(buf)
+
+		if level+1 == len(s.levels) {
+			s.levels = append(s.levels, nil)
+		}
+		for i := s.rng.IntN(2); i < len(buf); i += 2 {
+			s.levels[level+1] = append(s.levels[level+1], buf[i])
+		}
+		s.levels[level] = buf[:0]
+	}
+}
+
+// Merge adds the values summarized by "o" to "s"
+func (s *Sketch) Merge(o *Sketch) {
+	for level, buf := range o.levels {
+		for len(s.levels) <= level {
+			s.levels = append(s.levels, nil)
+		}
+		s.levels[level] = append(s.levels[level], buf...)
+	}
+	s.n += o.n
+	for level := range s.levels {
+		s.compact(level)
+	}
+}
+
+// Quantile returns an estimate of the "q"-quantile, "q" being between 0 and 1,
+// or NaN when the sketch is empty
+func (s *Sketch) Quantile(q float64) float64 {
+	type item struct {
+		value  float64
+		weight int
+	}
+
+	var items []item
+	total := 0
+	for level, buf := range s.levels {
+		w := 1 << level
+		for _, v := range buf {
+			items = append(items, item{v, w})
+			total += w
+		}
+	}
+	if total == 0 {
+		return math.NaN()
+	}
+
+	slices.SortFunc(items, func(a, b item) int {
+		switch {
+		case a.value < b.value:
+			return -1
+		case a.value > b.value:
+			return 1
+		}
+		return 0
+	})
+
+	target := math.Max(0, math.Min(1, q)) * float64(total)
+	cumulative := 0
+	for _, it := range items {
+		cumulative += it.weight
+		if float64(cumulative) >= target {
+			return it.value
+		}
+	}
+	return items[len(items)-1].value
+}
@@ -0,0 +1,54 @@
+package stats
+
+// The functions below are the slice forms of the variadic functions,
+// for callers holding their data in a slice rather than listing the values
+
+// SumOf returns the total of "nums", like Sum
+func SumOf[T Number](nums []T) T {
+	return Sum(nums...)
+}
+
+// MeanOf returns the arithmetic mean of "nums", like Mean
+func MeanOf[T Number](nums []T) (float64, error) {
+	return Mean(nums...)
+}
+
+// MedianOf returns the median of "nums", like Median
+func MedianOf[T Number](nums []T) (float64, error) {
+	return Median(nums...)
+}
+
+// ModeOf returns the most frequent values of "nums", like Mode
+func ModeOf[T Number](nums []T) ([]T, error) {
+	return Mode(nums...)
+}
+
+// VarianceOf returns the population variance of "nums", like Variance
+func VarianceOf[T Number](nums []T) (float64, error) {
+	return Variance(nums...)
+}
+
+// SampleVarianceOf returns the sample variance of "nums", like SampleVariance
+func SampleVarianceOf[T Number](nums []T) (float64, error) {
+	return SampleVariance(nums...)
+}
+
+// StdDevOf returns the population standard deviation of "nums", like StdDev
+func StdDevOf[T Number](nums []T) (float64, error) {
+	return StdDev(nums...)
+}
+
+// SampleStdDevOf returns the sample standard deviation of "nums", like SampleStdDev
+func SampleStdDevOf[T Number](nums []T) (float64, error) {
+	return SampleStdDev(nums...)
+}
+
+// PercentileOf returns the "p"-th percentile of "nums", like Percentile
+func PercentileOf[T Number](nums []T, p float64) (float64, error) {
+	return Percentile(p, nums...)
+}
+
+// HistogramOf counts the values of "nums" in "bins" bins of equal width, like Histogram
+func HistogramOf[T Number](nums []T, bins int) ([]Bin, error) {
+	return Histogram(bins, nums...)
+}
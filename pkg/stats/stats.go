@@ -0,0 +1,179 @@
+// Package stats computes descriptive statistics of numbers,
+// growing the variadic "sum" of the variadic functions example into
+// Sum, Mean, Median, Mode, Variance, StdDev, Percentile and Histogram.
+//
+// Every function is variadic, stats.Mean(1, 2, 3), and has a slice form
+// with the "Of" suffix, stats.MeanOf(nums), that does not need the "..." of the example.
+// For streams too large to keep in memory, use an Accumulator for the moments
+// and a Sketch for approximate quantiles.
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Number is any integer or floating point type
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// ErrEmpty is returned by the statistics that are not defined without data
+var ErrEmpty = errors.New("stats: no data")
+
+// Sum returns the total of "nums" in their own type, 0 when there are none
+func Sum[T Number](nums ...T) T {
+	var total T
+	for _, n := range nums {
+		total += n
+	}
+	return total
+}
+
+// Mean returns the arithmetic mean of "nums"
+func Mean[T Number](nums ...T) (float64, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmpty
+	}
+
+	// Sum as float64 so that integers cannot overflow
+	var total float64
+	for _, n := range nums {
+		total += float64(n)
+	}
+	return total / float64(len(nums)), nil
+}
+
+// Median returns the middle value of "nums" once sorted,
+// the mean of the two middle values for an even count
+func Median[T Number](nums ...T) (float64, error) {
+	return Percentile(50, nums...)
+}
+
+// Mode returns the most frequent values of "nums" in ascending order,
+// several of them when there is a tie
+func Mode[T Number](nums ...T) ([]T, error) {
+	if len(nums) == 0 {
+		return nil, ErrEmpty
+	}
+
+	counts := make(map[T]int, len(nums))
+	best := 0
+	for _, n := range nums {
+		counts[n]++
+		best = max(best, counts[n])
+	}
+
+	var modes []T
+	for n, c := range counts {
+		if c == best {
+			modes = append(modes, n)
+		}
+	}
+	slices.Sort(modes)
+	return modes, nil
+}
+
+// Variance returns the population variance of "nums", the mean squared distance to the mean
+func Variance[T Number](nums ...T) (float64, error) {
+	var a Accumulator
+	for _, n := range nums {
+		a.Add(float64(n))
+	}
+	if a.Count() == 0 {
+		return 0, ErrEmpty
+	}
+	return a.Variance(), nil
+}
+
+// SampleVariance returns the variance of "nums" as a sample of a larger population,
+// dividing by "n-1" instead of "n". It needs at least two values
+func SampleVariance[T Number](nums ...T) (float64, error) {
+	if len(nums) < 2 {
+		return 0, ErrEmpty
+	}
+	var a Accumulator
+	for _, n := range nums {
+		a.Add(float64(n))
+	}
+	return a.SampleVariance(), nil
+}
+
+// StdDev returns the population standard deviation of "nums"
+func StdDev[T Number](nums ...T) (float64, error) {
+	v, err := Variance(nums...)
+	return math.Sqrt(v), err
+}
+
+// SampleStdDev returns the sample standard deviation of "nums"
+func SampleStdDev[T Number](nums ...T) (float64, error) {
+	v, err := SampleVariance(nums...)
+	return math.Sqrt(v), err
+}
+
+// Percentile returns the "p"-th percentile of "nums", "p" being between 0 and 100,
+// interpolating linearly between the two closest ranks
+func Percentile[T Number](p float64, nums ...T) (float64, error) {
+	if len(nums) == 0 {
+		return 0, ErrEmpty
+	}
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, errors.New("stats: percentile must be between 0 and 100")
+	}
+
+	sorted := slices.Clone(nums)
+	slices.Sort(sorted)
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := min(lo+1, len(sorted)-1)
+	frac := rank - float64(lo)
+	return float64(sorted[lo]) + frac*(float64(sorted[hi])-float64(sorted[lo])), nil
+}
+
+// Bin is one bar of a histogram, counting the values in [Lo, Hi),
+// the last bin also including its upper bound
+type Bin struct {
+	Lo, Hi float64
+	Count  int
+}
+
+// Histogram splits the range of "nums" into "bins" bins of equal width and counts the values in each.
+// NaN and infinite values have no bin, they make it fail
+func Histogram[T Number](bins int, nums ...T) ([]Bin, error) {
+	if len(nums) == 0 {
+		return nil, ErrEmpty
+	}
+	if bins < 1 {
+		return nil, errors.New("stats: histogram needs at least one bin")
+	}
+	for i, n := range nums {
+		if f := float64(n); math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("stats: histogram of a non-finite value %v at index %d", f, i)
+		}
+	}
+
+	lo, hi := float64(slices.Min(nums)), float64(slices.Max(nums))
+	width := (hi - lo) / float64(bins)
+	if width == 0 {
+		// Every value is the same, a single bin of zero width holds them all
+		return []Bin{{Lo: lo, Hi: hi, Count: len(nums)}}, nil
+	}
+
+	hist := make([]Bin, bins)
+	for i := range hist {
+		hist[i].Lo = lo + float64(i)*width
+		hist[i].Hi = lo + float64(i+1)*width
+	}
+	hist[bins-1].Hi = hi
+
+	for _, n := range nums {
+		i := min(int((float64(n)-lo)/width), bins-1)
+		hist[i].Count++
+	}
+	return hist, nil
+}
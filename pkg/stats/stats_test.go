@@ -0,0 +1,213 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b))
+}
+
+func TestStatistics(t *testing.T) {
+	nums := []int{2, 4, 4, 4, 5, 5, 7, 9}
+
+	if got := Sum(nums...); got != 40 {
+		t.Errorf("Sum = %d", got)
+	}
+	tests := []struct {
+		name string
+		f    func(...int) (float64, error)
+		want float64
+	}{
+		{"Mean", Mean[int], 5},
+		{"Median", Median[int], 4.5},
+		{"Variance", Variance[int], 4},
+		{"StdDev", StdDev[int], 2},
+		{"SampleVariance", SampleVariance[int], 32.0 / 7},
+		{"SampleStdDev", SampleStdDev[int], math.Sqrt(32.0 / 7)},
+	}
+	for _, tt := range tests {
+		got, err := tt.f(nums...)
+		if err != nil || !near(got, tt.want) {
+			t.Errorf("%s = %v, %v, want %v", tt.name, got, err, tt.want)
+		}
+		if _, err := tt.f(); !errors.Is(err, ErrEmpty) {
+			t.Errorf("%s() = %v, want ErrEmpty", tt.name, err)
+		}
+	}
+
+	if modes, _ := Mode(nums...); !slices.Equal(modes, []int{4}) {
+		t.Errorf("Mode = %v", modes)
+	}
+	if modes, _ := Mode(3, 1, 3, 1, 2); !slices.Equal(modes, []int{1, 3}) {
+		t.Errorf("Mode with a tie = %v", modes)
+	}
+	if _, err := SampleVariance(1); !errors.Is(err, ErrEmpty) {
+		t.Errorf("SampleVariance of one value: %v", err)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	nums := []float64{15, 20, 35, 40, 50}
+	tests := []struct {
+		p, want float64
+	}{
+		{0, 15},
+		{25, 20},
+		{40, 29},
+		{50, 35},
+		{100, 50},
+	}
+	for _, tt := range tests {
+		if got, err := Percentile(tt.p, nums...); err != nil || !near(got, tt.want) {
+			t.Errorf("Percentile(%v) = %v, %v, want %v", tt.p, got, err, tt.want)
+		}
+	}
+	for _, p := range []float64{-1, 101, math.NaN()} {
+		if _, err := Percentile(p, nums...); err == nil {
+			t.Errorf("Percentile(%v) should fail", p)
+		}
+	}
+	if !slices.Equal(nums, []float64{15, 20, 35, 40, 50}) {
+		t.Error("Percentile modified its input")
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	hist, err := Histogram(4, 0, 1, 2, 3, 4, 5, 6, 7, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Bin{{0, 2, 2}, {2, 4, 2}, {4, 6, 2}, {6, 8, 3}}
+	if !slices.Equal(hist, want) {
+		t.Errorf("Histogram = %v, want %v", hist, want)
+	}
+
+	if hist, _ := Histogram(3, 5, 5, 5); !slices.Equal(hist, []Bin{{5, 5, 3}}) {
+		t.Errorf("Histogram of equal values = %v", hist)
+	}
+	if _, err := Histogram(0, 1, 2); err == nil {
+		t.Error("zero bins should fail")
+	}
+
+	for _, bad := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if hist, err := Histogram(3, 1.0, bad, 2.0); err == nil {
+			t.Errorf("Histogram with %v = %v, want an error", bad, hist)
+		}
+		if _, err := HistogramOf([]float64{bad}, 1); err == nil {
+			t.Errorf("HistogramOf with %v should fail", bad)
+		}
+	}
+}
+
+func TestSliceForms(t *testing.T) {
+	nums := []int8{100, 100, 50, -20}
+
+	if SumOf(nums) != Sum(nums...) || SumOf([]float64(nil)) != 0 {
+		t.Error("SumOf")
+	}
+	pairs := []struct {
+		name       string
+		slice, vad func() (float64, error)
+	}{
+		{"MeanOf", func() (float64, error) { return MeanOf(nums) }, func() (float64, error) { return Mean(nums...) }},
+		{"MedianOf", func() (float64, error) { return MedianOf(nums) }, func() (float64, error) { return Median(nums...) }},
+		{"VarianceOf", func() (float64, error) { return VarianceOf(nums) }, func() (float64, error) { return Variance(nums...) }},
+		{"SampleVarianceOf", func() (float64, error) { return SampleVarianceOf(nums) }, func() (float64, error) { return SampleVariance(nums...) }},
+		{"StdDevOf", func() (float64, error) { return StdDevOf(nums) }, func() (float64, error) { return StdDev(nums...) }},
+		{"SampleStdDevOf", func() (float64, error) { return SampleStdDevOf(nums) }, func() (float64, error) { return SampleStdDev(nums...) }},
+		{"PercentileOf", func() (float64, error) { return PercentileOf(nums, 90) }, func() (float64, error) { return Percentile(90, nums...) }},
+	}
+	for _, p := range pairs {
+		a, errA := p.slice()
+		b, errB := p.vad()
+		if a != b || errA != errB {
+			t.Errorf("%s = %v, %v; variadic form %v, %v", p.name, a, errA, b, errB)
+		}
+	}
+
+	// The mean does not overflow the element type
+	if mean, _ := MeanOf(nums); mean != 57.5 {
+		t.Errorf("MeanOf = %v", mean)
+	}
+	if modes, _ := ModeOf(nums); !slices.Equal(modes, []int8{100}) {
+		t.Errorf("ModeOf = %v", modes)
+	}
+	if hist, _ := HistogramOf(nums, 2); len(hist) != 2 || hist[0].Count+hist[1].Count != len(nums) {
+		t.Errorf("HistogramOf = %v", hist)
+	}
+}
+
+func TestAccumulator(t *testing.T) {
+	var empty Accumulator
+	if !math.IsNaN(empty.Mean()) || !math.IsNaN(empty.Variance()) || !math.IsNaN(empty.Min()) {
+		t.Error("an empty accumulator should return NaN")
+	}
+
+	rng := rand.New(rand.NewPCG(3, 4))
+	nums := make([]float64, 10_000)
+	for i := range nums {
+		// A large offset loses precision when summing squares
+		nums[i] = 1e9 + rng.NormFloat64()
+	}
+
+	var all, left, right Accumulator
+	for i, x := range nums {
+		all.Add(x)
+		if i < 3000 {
+			left.Add(x)
+		} else {
+			right.Add(x)
+		}
+	}
+	left.Merge(right)
+
+	mean, _ := Mean(nums...)
+	variance := 0.0
+	for _, x := range nums {
+		variance += (x - mean) * (x - mean)
+	}
+	variance /= float64(len(nums))
+
+	for _, a := range []Accumulator{all, left} {
+		if a.Count() != len(nums) || !near(a.Mean(), mean) || math.Abs(a.Variance()-variance) > 1e-6 {
+			t.Errorf("count %d, mean %v, variance %v; want %v, %v", a.Count(), a.Mean(), a.Variance(), mean, variance)
+		}
+		if a.Min() != slices.Min(nums) || a.Max() != slices.Max(nums) {
+			t.Errorf("min %v, max %v", a.Min(), a.Max())
+		}
+	}
+}
+
+func TestSketch(t *testing.T) {
+	if !math.IsNaN(NewSketch(0).Quantile(0.5)) {
+		t.Error("an empty sketch should return NaN")
+	}
+
+	const n = 100_000
+	rng := rand.New(rand.NewPCG(5, 6))
+	a, b := NewSketch(0), NewSketch(0)
+	for i, v := range rng.Perm(n) {
+		if i%2 == 0 {
+			a.Add(float64(v))
+		} else {
+			b.Add(float64(v))
+		}
+	}
+	a.Merge(b)
+
+	if a.Count() != n {
+		t.Errorf("Count = %d", a.Count())
+	}
+	// The values are 0 to n-1, so the rank of a value is the value itself
+	for _, q := range []float64{0.01, 0.1, 0.5, 0.9, 0.99} {
+		got := a.Quantile(q)
+		if rankErr := math.Abs(got/n - q); rankErr > 0.02 {
+			t.Errorf("Quantile(%v) = %v, rank error %.3f", q, got, rankErr)
+		}
+	}
+}
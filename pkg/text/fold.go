@@ -0,0 +1,45 @@
+package text
+
+import (
+	"strings"
+)
+
+// Fold returns "s" with its full case folding applied,
+// a form for case-insensitive comparison where e.g. "ß" and "SS" both become "ss"
+func Fold(s string) string {
+	if isASCII(s) {
+		return strings.ToLower(s)
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if f, ok := caseFolds[r]; ok {
+			b.WriteString(f)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// foldKey returns the form in which canonical caseless matching compares strings,
+// NFD(Fold(NFD(s))) as defined by the Unicode Standard
+func foldKey(s string) string {
+	return NFD(Fold(NFD(s)))
+}
+
+// EqualFold reports whether "a" and "b" are equal ignoring case and canonical equivalence.
+// Unlike "strings.EqualFold", it applies the full case folding, so "Straße" equals "STRASSE"
+func EqualFold(a, b string) bool {
+	if isASCII(a) && isASCII(b) {
+		return strings.EqualFold(a, b)
+	}
+	return foldKey(a) == foldKey(b)
+}
+
+// CompareFold compares "a" and "b" like "strings.Compare" after folding their case,
+// for sorting case-insensitively
+func CompareFold(a, b string) int {
+	return strings.Compare(foldKey(a), foldKey(b))
+}
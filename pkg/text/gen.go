@@ -0,0 +1,317 @@
+//go:build ignore
+
+// Gen builds tables.go from files of the Unicode Character Database:
+// UnicodeData.txt for combining classes and canonical decompositions,
+// CompositionExclusions.txt, EastAsianWidth.txt and CaseFolding.txt.
+//
+// Usage:
+//
+//	go run gen.go [-ucd https://www.unicode.org/Public/17.0.0/ucd] [-version 17.0.0] [-out tables.go]
+//
+// The -ucd flag also accepts a local directory holding the same files
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	version = flag.String("version", "17.0.0", "version of the Unicode Character Database")
+	ucd     = flag.String("ucd", "", "URL or directory of the database, the unicode.org one for -version by default")
+	out     = flag.String("out", "tables.go", "file to write")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gen: ")
+	flag.Parse()
+	if *ucd == "" {
+		*ucd = "https://www.unicode.org/Public/" + *version + "/ucd"
+	}
+
+	classes, decomps := parseUnicodeData(open("UnicodeData.txt"))
+	excluded := parseExclusions(open("CompositionExclusions.txt"))
+	wide := parseEastAsianWidth(open("EastAsianWidth.txt"))
+	folds := parseCaseFolding(open("CaseFolding.txt"))
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by \"go run gen.go\"; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package text\n\n")
+	fmt.Fprintf(&b, "// UnicodeVersion is the version of the Unicode Character Database the tables come from\n")
+	fmt.Fprintf(&b, "const UnicodeVersion = %q\n\n", *version)
+
+	writeClasses(&b, classes)
+	writeDecompositions(&b, classes, decomps)
+	writeCompositions(&b, classes, decomps, excluded)
+	writeRanges(&b, "wideRanges", "lists the runes with an East Asian Width of W or F, as sorted ranges", wide)
+	writeFolds(&b, folds)
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// open returns the content of a database file, downloaded or read from disk
+func open(name string) []byte {
+	if strings.HasPrefix(*ucd, "http://") || strings.HasPrefix(*ucd, "https://") {
+		resp, err := http.Get(*ucd + "/" + name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			log.Fatalf("%s: %s", name, resp.Status)
+		}
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return data
+	}
+
+	data, err := os.ReadFile(filepath.Join(*ucd, name))
+	if err != nil {
+		log.Fatal(err)
+	}
+	return data
+}
+
+// records calls "f" with the fields of every line, without comments and blank lines
+func records(data []byte, f func(fields []string)) {
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line, _, _ := strings.Cut(sc.Text(), "#")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, ";")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		f(fields)
+	}
+	if err := sc.Err(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parseRune(s string) rune {
+	n, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		log.Fatalf("invalid code point %q", s)
+	}
+	return rune(n)
+}
+
+func parseRunes(s string) []rune {
+	var runes []rune
+	for _, f := range strings.Fields(s) {
+		runes = append(runes, parseRune(f))
+	}
+	return runes
+}
+
+// parseRange reads "0041" or "0041..005A"
+func parseRange(s string) (lo, hi rune) {
+	first, last, ok := strings.Cut(s, "..")
+	lo = parseRune(first)
+	if !ok {
+		return lo, lo
+	}
+	return lo, parseRune(last)
+}
+
+// parseUnicodeData returns the non-zero canonical combining classes
+// and the one-level canonical decompositions
+func parseUnicodeData(data []byte) (map[rune]uint8, map[rune][]rune) {
+	classes := map[rune]uint8{}
+	decomps := map[rune][]rune{}
+	records(data, func(fields []string) {
+		if len(fields) < 6 {
+			log.Fatalf("UnicodeData.txt: short record %q", fields)
+		}
+		r := parseRune(fields[0])
+		if ccc, _ := strconv.Atoi(fields[3]); ccc != 0 {
+			classes[r] = uint8(ccc)
+		}
+		// Compatibility decompositions start with a tag such as "<compat>"
+		if d := fields[5]; d != "" && !strings.HasPrefix(d, "<") {
+			decomps[r] = parseRunes(d)
+		}
+	})
+	return classes, decomps
+}
+
+func parseExclusions(data []byte) map[rune]bool {
+	excluded := map[rune]bool{}
+	records(data, func(fields []string) {
+		excluded[parseRune(fields[0])] = true
+	})
+	return excluded
+}
+
+// parseEastAsianWidth returns the ranges of wide and fullwidth runes,
+// including the unassigned ones that default to wide
+func parseEastAsianWidth(data []byte) []runeRange {
+	wide := make([]bool, 0x110000)
+
+	// Defaults come first in "# @missing: 3400..4DBF; W" lines, which would be skipped as comments
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line, ok := strings.CutPrefix(sc.Text(), "# @missing:")
+		if !ok {
+			continue
+		}
+		spec, value, _ := strings.Cut(line, ";")
+		lo, hi := parseRange(strings.TrimSpace(spec))
+		for r := lo; r <= hi; r++ {
+			wide[r] = isWide(value)
+		}
+	}
+
+	records(data, func(fields []string) {
+		lo, hi := parseRange(fields[0])
+		for r := lo; r <= hi; r++ {
+			wide[r] = isWide(fields[1])
+		}
+	})
+
+	var ranges []runeRange
+	for r, w := range wide {
+		switch {
+		case !w:
+		case len(ranges) > 0 && ranges[len(ranges)-1].hi == rune(r)-1:
+			ranges[len(ranges)-1].hi = rune(r)
+		default:
+			ranges = append(ranges, runeRange{rune(r), rune(r)})
+		}
+	}
+	return ranges
+}
+
+func isWide(value string) bool {
+	value = strings.TrimSpace(value)
+	return value == "W" || value == "F"
+}
+
+// parseCaseFolding returns the common and full foldings, which together make the full case folding
+func parseCaseFolding(data []byte) map[rune][]rune {
+	folds := map[rune][]rune{}
+	records(data, func(fields []string) {
+		if fields[1] == "C" || fields[1] == "F" {
+			folds[parseRune(fields[0])] = parseRunes(fields[2])
+		}
+	})
+	return folds
+}
+
+type runeRange struct{ lo, hi rune }
+
+func sortedKeys[V any](m map[rune]V) []rune {
+	keys := make([]rune, 0, len(m))
+	for r := range m {
+		keys = append(keys, r)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+func writeClasses(b *bytes.Buffer, classes map[rune]uint8) {
+	fmt.Fprintf(b, "// combiningClasses lists the runes with a non-zero canonical combining class, as sorted ranges\n")
+	fmt.Fprintf(b, "var combiningClasses = []classRange{\n")
+
+	keys := sortedKeys(classes)
+	for i := 0; i < len(keys); {
+		j := i
+		for j+1 < len(keys) && keys[j+1] == keys[j]+1 && classes[keys[j+1]] == classes[keys[i]] {
+			j++
+		}
+		fmt.Fprintf(b, "{0x%04X, 0x%04X, %d},\n", keys[i], keys[j], classes[keys[i]])
+		i = j + 1
+	}
+	fmt.Fprintf(b, "}\n\n")
+}
+
+// decompose expands a decomposition recursively and puts it in canonical order
+func decompose(r rune, classes map[rune]uint8, decomps map[rune][]rune) []rune {
+	d, ok := decomps[r]
+	if !ok {
+		return []rune{r}
+	}
+	var full []rune
+	for _, x := range d {
+		full = append(full, decompose(x, classes, decomps)...)
+	}
+
+	// Canonical ordering swaps adjacent marks until their classes never decrease
+	for i := 1; i < len(full); i++ {
+		for j := i; j > 0; j-- {
+			cj, cp := classes[full[j]], classes[full[j-1]]
+			if cj == 0 || cp <= cj {
+				break
+			}
+			full[j], full[j-1] = full[j-1], full[j]
+		}
+	}
+	return full
+}
+
+func writeDecompositions(b *bytes.Buffer, classes map[rune]uint8, decomps map[rune][]rune) {
+	fmt.Fprintf(b, "// decompositions maps runes to their full canonical decomposition, in canonical order.\n")
+	fmt.Fprintf(b, "// Hangul syllables are decomposed by an algorithm instead\n")
+	fmt.Fprintf(b, "var decompositions = map[rune]string{\n")
+	for _, r := range sortedKeys(decomps) {
+		fmt.Fprintf(b, "0x%04X: %+q,\n", r, string(decompose(r, classes, decomps)))
+	}
+	fmt.Fprintf(b, "}\n\n")
+}
+
+func writeCompositions(b *bytes.Buffer, classes map[rune]uint8, decomps map[rune][]rune, excluded map[rune]bool) {
+	fmt.Fprintf(b, "// compositions maps pairs of runes to their primary composite.\n")
+	fmt.Fprintf(b, "// Hangul syllables are composed by an algorithm instead\n")
+	fmt.Fprintf(b, "var compositions = map[[2]rune]rune{\n")
+	for _, r := range sortedKeys(decomps) {
+		d := decomps[r]
+
+		// Singletons and decompositions starting with a non-starter are never recomposed
+		if len(d) != 2 || excluded[r] || classes[r] != 0 || classes[d[0]] != 0 {
+			continue
+		}
+		fmt.Fprintf(b, "{0x%04X, 0x%04X}: 0x%04X,\n", d[0], d[1], r)
+	}
+	fmt.Fprintf(b, "}\n\n")
+}
+
+func writeRanges(b *bytes.Buffer, name, doc string, ranges []runeRange) {
+	fmt.Fprintf(b, "// %s %s\n", name, doc)
+	fmt.Fprintf(b, "var %s = []runeRange{\n", name)
+	for _, r := range ranges {
+		fmt.Fprintf(b, "{0x%04X, 0x%04X},\n", r.lo, r.hi)
+	}
+	fmt.Fprintf(b, "}\n\n")
+}
+
+func writeFolds(b *bytes.Buffer, folds map[rune][]rune) {
+	fmt.Fprintf(b, "// caseFolds maps runes to their full case folding\n")
+	fmt.Fprintf(b, "var caseFolds = map[rune]string{\n")
+	for _, r := range sortedKeys(folds) {
+		fmt.Fprintf(b, "0x%04X: %+q,\n", r, string(folds[r]))
+	}
+	fmt.Fprintf(b, "}\n")
+}
@@ -0,0 +1,195 @@
+package text
+
+import (
+	"iter"
+	"unicode"
+	"unicode/utf8"
+)
+
+// graphemeClass is the Grapheme_Cluster_Break property of a rune, see Unicode Standard Annex #29
+type graphemeClass uint8
+
+const (
+	gbOther graphemeClass = iota
+	gbCR
+	gbLF
+	gbControl
+	gbExtend
+	gbZWJ
+	gbRegionalIndicator
+	gbPrepend
+	gbSpacingMark
+	gbL
+	gbV
+	gbT
+	gbLV
+	gbLVT
+)
+
+// prepend lists the prepended concatenation marks, the common Prepend runes
+var prepend = []runeRange{
+	{0x0600, 0x0605}, {0x06DD, 0x06DD}, {0x070F, 0x070F}, {0x0890, 0x0891},
+	{0x08E2, 0x08E2}, {0x110BD, 0x110BD}, {0x110CD, 0x110CD},
+}
+
+// pictographic approximates the Extended_Pictographic property of emoji-data.txt,
+// which the standard library does not provide, with the blocks of emoji and symbols
+var pictographic = []runeRange{
+	{0x00A9, 0x00A9}, {0x00AE, 0x00AE}, {0x203C, 0x203C}, {0x2049, 0x2049},
+	{0x2122, 0x2122}, {0x2139, 0x2139}, {0x2194, 0x2199}, {0x21A9, 0x21AA},
+	{0x231A, 0x231B}, {0x2328, 0x2328}, {0x2388, 0x2388}, {0x23CF, 0x23CF},
+	{0x23E9, 0x23F3}, {0x23F8, 0x23FA}, {0x24C2, 0x24C2}, {0x25AA, 0x25AB},
+	{0x25B6, 0x25B6}, {0x25C0, 0x25C0}, {0x25FB, 0x25FE}, {0x2600, 0x2605},
+	{0x2607, 0x2612}, {0x2614, 0x2685}, {0x2690, 0x2705}, {0x2708, 0x2712},
+	{0x2714, 0x2714}, {0x2716, 0x2716}, {0x271D, 0x271D}, {0x2721, 0x2721},
+	{0x2728, 0x2728}, {0x2733, 0x2734}, {0x2744, 0x2744}, {0x2747, 0x2747},
+	{0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755}, {0x2757, 0x2757},
+	{0x2763, 0x2767}, {0x2795, 0x2797}, {0x27A1, 0x27A1}, {0x27B0, 0x27B0},
+	{0x27BF, 0x27BF}, {0x2934, 0x2935}, {0x2B05, 0x2B07}, {0x2B1B, 0x2B1C},
+	{0x2B50, 0x2B50}, {0x2B55, 0x2B55}, {0x3030, 0x3030}, {0x303D, 0x303D},
+	{0x3297, 0x3297}, {0x3299, 0x3299}, {0x1F000, 0x1F0FF}, {0x1F10D, 0x1F10F},
+	{0x1F12F, 0x1F12F}, {0x1F16C, 0x1F171}, {0x1F17E, 0x1F17F}, {0x1F18E, 0x1F18E},
+	{0x1F191, 0x1F19A}, {0x1F1AD, 0x1F1E5}, {0x1F201, 0x1F20F}, {0x1F21A, 0x1F21A},
+	{0x1F22F, 0x1F22F}, {0x1F232, 0x1F23A}, {0x1F23C, 0x1F23F}, {0x1F249, 0x1F3FA},
+	{0x1F400, 0x1F53D}, {0x1F546, 0x1F64F}, {0x1F680, 0x1F6FF}, {0x1F774, 0x1F77F},
+	{0x1F7D5, 0x1F7FF}, {0x1F80C, 0x1F80F}, {0x1F848, 0x1F84F}, {0x1F85A, 0x1F85F},
+	{0x1F888, 0x1F88F}, {0x1F8AE, 0x1F8FF}, {0x1F90C, 0x1F93A}, {0x1F93C, 0x1F945},
+	{0x1F947, 0x1FAFF}, {0x1FC00, 0x1FFFD},
+}
+
+func classOf(r rune) graphemeClass {
+	switch {
+	case r < 0x7F && r >= 0x20:
+		return gbOther
+	case r == '\r':
+		return gbCR
+	case r == '\n':
+		return gbLF
+	case r == 0x200D:
+		return gbZWJ
+	case r >= 0x1F1E6 && r <= 0x1F1FF:
+		return gbRegionalIndicator
+	case r >= 0x1F3FB && r <= 0x1F3FF:
+		// Emoji skin tone modifiers
+		return gbExtend
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return gbL
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return gbV
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return gbT
+	case r >= hangulBase && r < hangulBase+hangulCount:
+		if (r-hangulBase)%hangulTCount == 0 {
+			return gbLV
+		}
+		return gbLVT
+	case inRanges(prepend, r):
+		return gbPrepend
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Other_Grapheme_Extend):
+		return gbExtend
+	case unicode.In(r, unicode.Cc, unicode.Cf, unicode.Zl, unicode.Zp):
+		return gbControl
+	case unicode.Is(unicode.Mc, r), r == 0x0E33, r == 0x0EB3:
+		return gbSpacingMark
+	}
+	return gbOther
+}
+
+// graphemeLen returns the length in bytes of the first grapheme cluster of "s",
+// following the rules of Unicode Standard Annex #29 except the one for Indic conjuncts
+func graphemeLen(s string) int {
+	r, i := utf8.DecodeRuneInString(s)
+	prev := classOf(r)
+	emoji := inRanges(pictographic, r) // inside "Extended_Pictographic Extend*"
+	regional := 0                      // regional indicators just before
+	if prev == gbRegionalIndicator {
+		regional = 1
+	}
+
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		cur := classOf(r)
+		pict := inRanges(pictographic, r)
+		if breaks(prev, cur, emoji && pict, regional) {
+			break
+		}
+
+		switch {
+		case pict:
+			emoji = true
+		case cur == gbExtend && prev != gbZWJ, cur == gbZWJ && prev != gbZWJ:
+		default:
+			emoji = false
+		}
+		if cur == gbRegionalIndicator {
+			regional++
+		} else {
+			regional = 0
+		}
+		prev = cur
+		i += size
+	}
+	return i
+}
+
+// breaks reports whether there is a grapheme cluster boundary between two runes.
+// "emoji" tells whether an emoji after a ZWJ continues an emoji sequence
+func breaks(prev, cur graphemeClass, emoji bool, regional int) bool {
+	switch {
+	case prev == gbCR && cur == gbLF:
+		return false
+	case prev == gbCR || prev == gbLF || prev == gbControl:
+		return true
+	case cur == gbCR || cur == gbLF || cur == gbControl:
+		return true
+	case prev == gbL && (cur == gbL || cur == gbV || cur == gbLV || cur == gbLVT):
+		return false
+	case (prev == gbLV || prev == gbV) && (cur == gbV || cur == gbT):
+		return false
+	case (prev == gbLVT || prev == gbT) && cur == gbT:
+		return false
+	case cur == gbExtend || cur == gbZWJ || cur == gbSpacingMark || prev == gbPrepend:
+		return false
+	case prev == gbZWJ && emoji:
+		return false
+	case prev == gbRegionalIndicator && cur == gbRegionalIndicator:
+		// Flags are pairs of regional indicators
+		return regional%2 == 0
+	}
+	return true
+}
+
+// Graphemes yields the grapheme clusters of "s", the characters a reader sees,
+// e.g. "e" followed by a combining accent, or a family emoji made of several joined emoji
+func Graphemes(s string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for s != "" {
+			n := graphemeLen(s)
+			if !yield(s[:n]) {
+				return
+			}
+			s = s[n:]
+		}
+	}
+}
+
+// GraphemeCount returns the number of grapheme clusters of "s",
+// which is its length for a reader where "len" counts bytes and "utf8.RuneCountInString" runes
+func GraphemeCount(s string) int {
+	n := 0
+	for s != "" {
+		s = s[graphemeLen(s):]
+		n++
+	}
+	return n
+}
+
+// Truncate returns the first "n" grapheme clusters of "s",
+// never cutting a character in the middle
+func Truncate(s string, n int) string {
+	i := 0
+	for ; n > 0 && i < len(s); n-- {
+		i += graphemeLen(s[i:])
+	}
+	return s[:i]
+}
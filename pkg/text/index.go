@@ -0,0 +1,87 @@
+package text
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+// checkpoint is the number of runes between two recorded byte offsets of an Index
+const checkpoint = 32
+
+// Index converts between the byte offsets that indexing and ranging over a string use
+// and the rune positions that a user counts, in a few steps instead of rescanning the string.
+// An invalid UTF-8 byte counts as one rune, as it does when ranging over a string
+type Index struct {
+	s       string
+	runes   int
+	offsets []int // byte offset of every "checkpoint"-th rune
+}
+
+// NewIndex scans "s" once to build its index
+func NewIndex(s string) *Index {
+	x := &Index{s: s}
+	for i := 0; i < len(s); x.runes++ {
+		if x.runes%checkpoint == 0 {
+			x.offsets = append(x.offsets, i)
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return x
+}
+
+// RuneCount returns the number of runes of the string
+func (x *Index) RuneCount() int {
+	return x.runes
+}
+
+// ByteOffset returns the byte offset where the rune at position "i" starts,
+// the length of the string when "i" is RuneCount.
+// It panics when "i" is out of range, like indexing a slice
+func (x *Index) ByteOffset(i int) int {
+	if i < 0 || i > x.runes {
+		panic(fmt.Sprintf("text: rune index %d out of range [0:%d]", i, x.runes))
+	}
+	switch {
+	case i == x.runes:
+		return len(x.s)
+	case x.runes == len(x.s):
+		// Every rune is a single byte
+		return i
+	}
+
+	offset := x.offsets[i/checkpoint]
+	for n := i % checkpoint; n > 0; n-- {
+		_, size := utf8.DecodeRuneInString(x.s[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// RuneIndex returns the position of the rune that contains the byte at "offset",
+// RuneCount when "offset" is the length of the string.
+// It panics when "offset" is out of range
+func (x *Index) RuneIndex(offset int) int {
+	if offset < 0 || offset > len(x.s) {
+		panic(fmt.Sprintf("text: byte offset %d out of range [0:%d]", offset, len(x.s)))
+	}
+	switch {
+	case offset == len(x.s):
+		return x.runes
+	case x.runes == len(x.s):
+		return offset
+	}
+
+	// Start from the last checkpoint at or before the offset
+	k := sort.Search(len(x.offsets), func(k int) bool { return x.offsets[k] > offset }) - 1
+	i, pos := x.offsets[k], k*checkpoint
+	for {
+		_, size := utf8.DecodeRuneInString(x.s[i:])
+		if i+size > offset {
+			return pos
+		}
+		i += size
+		pos++
+	}
+}
@@ -0,0 +1,140 @@
+package text
+
+import "unicode/utf8"
+
+// The Hangul syllables are composed of a leading consonant (L), a vowel (V)
+// and an optional trailing consonant (T), and are decomposed by arithmetic
+const (
+	hangulBase   = 0xAC00
+	hangulL      = 0x1100
+	hangulV      = 0x1161
+	hangulT      = 0x11A7
+	hangulLCount = 19
+	hangulVCount = 21
+	hangulTCount = 28
+	hangulNCount = hangulVCount * hangulTCount
+	hangulCount  = hangulLCount * hangulNCount
+)
+
+// NFD returns "s" in Normalization Form D: every rune canonically decomposed,
+// e.g. "é" (U+00E9) becomes "e" followed by the combining acute accent (U+0301)
+func NFD(s string) string {
+	if isASCII(s) {
+		return s
+	}
+	return string(decompose(s))
+}
+
+// NFC returns "s" in Normalization Form C: canonically decomposed then recomposed,
+// the form most text is already in and the one to use before comparing or storing strings
+func NFC(s string) string {
+	if isASCII(s) {
+		return s
+	}
+	return string(compose(decompose(s)))
+}
+
+// IsNFC reports whether "s" is already in Normalization Form C
+func IsNFC(s string) bool {
+	return NFC(s) == s
+}
+
+// IsNFD reports whether "s" is already in Normalization Form D
+func IsNFD(s string) bool {
+	return NFD(s) == s
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// decompose returns the runes of "s" fully decomposed, in canonical order
+func decompose(s string) []rune {
+	runes := make([]rune, 0, len(s))
+	for _, r := range s {
+		switch d, ok := decompositions[r]; {
+		case ok:
+			runes = append(runes, []rune(d)...)
+		case r >= hangulBase && r < hangulBase+hangulCount:
+			i := r - hangulBase
+			runes = append(runes, hangulL+i/hangulNCount, hangulV+i%hangulNCount/hangulTCount)
+			if t := i % hangulTCount; t != 0 {
+				runes = append(runes, hangulT+t)
+			}
+		default:
+			runes = append(runes, r)
+		}
+	}
+	reorder(runes)
+	return runes
+}
+
+// reorder sorts every run of combining marks by combining class, keeping the order
+// of marks of the same class: the canonical ordering algorithm
+func reorder(runes []rune) {
+	for i := 1; i < len(runes); i++ {
+		c := combiningClass(runes[i])
+		if c == 0 {
+			continue
+		}
+		for j := i; j > 0; j-- {
+			p := combiningClass(runes[j-1])
+			if p <= c {
+				break
+			}
+			runes[j], runes[j-1] = runes[j-1], runes[j]
+		}
+	}
+}
+
+// compose applies the canonical composition algorithm to decomposed runes:
+// a mark combines with the last starter unless another mark of the same
+// or a higher class sits between them
+func compose(runes []rune) []rune {
+	out := runes[:0]
+	starter, last := -1, uint8(0)
+	for _, r := range runes {
+		c := combiningClass(r)
+		if starter >= 0 {
+			adjacent := starter == len(out)-1
+			if adjacent || (last != 0 && last < c) {
+				if composite, ok := composePair(out[starter], r); ok {
+					out[starter] = composite
+					continue
+				}
+			}
+		}
+
+		if c == 0 {
+			starter = len(out)
+		}
+		out = append(out, r)
+		last = c
+	}
+	return out
+}
+
+func composePair(a, b rune) (rune, bool) {
+	// LV syllable from L and V, then LVT from LV and T
+	if a >= hangulL && a < hangulL+hangulLCount && b >= hangulV && b < hangulV+hangulVCount {
+		return hangulBase + ((a-hangulL)*hangulVCount+b-hangulV)*hangulTCount, true
+	}
+	if a >= hangulBase && a < hangulBase+hangulCount && (a-hangulBase)%hangulTCount == 0 &&
+		b > hangulT && b < hangulT+hangulTCount {
+		return a + b - hangulT, true
+	}
+
+	r, ok := compositions[[2]rune{a, b}]
+	return r, ok
+}
+
+// Equivalent reports whether "a" and "b" are canonically equivalent,
+// e.g. "é" as one rune and as "e" followed by a combining accent
+func Equivalent(a, b string) bool {
+	return NFD(a) == NFD(b)
+}
@@ -0,0 +1,42 @@
+// Package text goes past the range over a string of the range example, which yields
+// the byte index and the code point of every rune, to what a reader sees:
+// user-perceived characters (grapheme clusters), their width on a terminal,
+// case-insensitive comparison, canonical normalization, and conversion between
+// rune and byte positions.
+//
+// The standard library has no normalization or width data, so the tables of tables.go
+// are generated from the Unicode Character Database by gen.go
+package text
+
+import "sort"
+
+//go:generate go run gen.go
+
+// runeRange is an inclusive range of runes
+type runeRange struct {
+	lo, hi rune
+}
+
+// classRange gives the canonical combining class of a range of runes
+type classRange struct {
+	lo, hi rune
+	class  uint8
+}
+
+// inRanges reports whether "r" is in one of the sorted ranges
+func inRanges(ranges []runeRange, r rune) bool {
+	i := sort.Search(len(ranges), func(i int) bool { return ranges[i].hi >= r })
+	return i < len(ranges) && ranges[i].lo <= r
+}
+
+// combiningClass returns the canonical combining class of "r", 0 for starters
+func combiningClass(r rune) uint8 {
+	if r < 0x300 {
+		return 0
+	}
+	i := sort.Search(len(combiningClasses), func(i int) bool { return combiningClasses[i].hi >= r })
+	if i < len(combiningClasses) && combiningClasses[i].lo <= r {
+		return combiningClasses[i].class
+	}
+	return 0
+}
@@ -0,0 +1,321 @@
+package text
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+const (
+	family = "\U0001F468\u200D\U0001F469\u200D\U0001F467" // man, woman and girl joined
+	france = "\U0001F1EB\U0001F1F7"
+	german = "\U0001F1E9\U0001F1EA"
+)
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []string
+	}{
+		{"", nil},
+		{"abc", []string{"a", "b", "c"}},
+		{"e\u0301x", []string{"e\u0301", "x"}},
+		{"a\r\nb", []string{"a", "\r\n", "b"}},
+		{"\n\r", []string{"\n", "\r"}},
+		{"a\u0300\u0323", []string{"a\u0300\u0323"}},
+		{family + "!", []string{family, "!"}},
+		{"\U0001F44D\U0001F3FD", []string{"\U0001F44D\U0001F3FD"}},
+		{"\u2764\uFE0F", []string{"\u2764\uFE0F"}},
+		{france + german, []string{france, german}},
+		{france + "\U0001F1E9", []string{france, "\U0001F1E9"}},
+		{"a\u200Db", []string{"a\u200D", "b"}},
+		{"\u1100\u1161\u11A8\u1100", []string{"\u1100\u1161\u11A8", "\u1100"}},
+		{"\uD55C\u1161", []string{"\uD55C", "\u1161"}},
+		{"\uD55C\u11AB", []string{"\uD55C\u11AB"}},
+		{"\uD558\u11AB", []string{"\uD558\u11AB"}},
+		{"\u0600\u0661", []string{"\u0600\u0661"}},
+		{"\u0915\u093E", []string{"\u0915\u093E"}},
+		{"\xff\xfe", []string{"\xff", "\xfe"}},
+	}
+	for _, tt := range tests {
+		got := slices.Collect(Graphemes(tt.s))
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Graphemes(%+q) = %+q, want %+q", tt.s, got, tt.want)
+		}
+		if n := GraphemeCount(tt.s); n != len(tt.want) {
+			t.Errorf("GraphemeCount(%+q) = %d, want %d", tt.s, n, len(tt.want))
+		}
+	}
+}
+
+func TestGraphemesStop(t *testing.T) {
+	var got []string
+	for g := range Graphemes("abc") {
+		got = append(got, g)
+		if g == "b" {
+			break
+		}
+	}
+	if !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("breaking out of Graphemes yielded %q", got)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"hello", 3, "hel"},
+		{"hello", 10, "hello"},
+		{"hello", 0, ""},
+		{"e\u0301e\u0301", 1, "e\u0301"},
+		{family + family, 1, family},
+		{france + german, 1, france},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.s, tt.n); got != tt.want {
+			t.Errorf("Truncate(%+q, %d) = %+q, want %+q", tt.s, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"hello", 5},
+		{"\t\x1b", 0},
+		{"\u65E5\u672C\u8A9E", 6},
+		{"\uFF48\uFF49", 4},
+		{"e\u0301", 1},
+		{"\u200B", 0},
+		{"\U0001F44D", 2},
+		{"\U0001F44D\U0001F3FD", 2},
+		{"\u2764", 1},
+		{"\u2764\uFE0F", 2},
+		{france, 2},
+		{family, 2},
+		{"\u1100\u1161\u11A8", 2},
+		{"\uD55C", 2},
+		{"a\u0301b", 2},
+	}
+	for _, tt := range tests {
+		if got := Width(tt.s); got != tt.want {
+			t.Errorf("Width(%+q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateWidth(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		tail  string
+		want  string
+	}{
+		{"hello", 5, "\u2026", "hello"},
+		{"hello", 4, "\u2026", "hel\u2026"},
+		{"hello", 4, "", "hell"},
+		{"\u65E5\u672C\u8A9E", 5, "\u2026", "\u65E5\u672C\u2026"},
+		{"\u65E5\u672C\u8A9E", 4, "\u2026", "\u65E5\u2026"},
+		{"\u65E5\u672C\u8A9E", 3, "", "\u65E5"},
+		{"e\u0301te\u0301", 2, "", "e\u0301t"},
+		{"hello", 2, "...", ""},
+		{france + german, 3, "", france},
+	}
+	for _, tt := range tests {
+		got := TruncateWidth(tt.s, tt.width, tt.tail)
+		if got != tt.want {
+			t.Errorf("TruncateWidth(%+q, %d, %q) = %+q, want %+q", tt.s, tt.width, tt.tail, got, tt.want)
+		}
+		if w := Width(got); w > tt.width {
+			t.Errorf("TruncateWidth(%+q, %d, %q) is %d columns wide", tt.s, tt.width, tt.tail, w)
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		s, want string
+	}{
+		{"Hello", "hello"},
+		{"Stra\u00DFe", "strasse"},
+		{"\u03A3\u0391\u03A3", "\u03C3\u03B1\u03C3"},
+		{"\uFB01", "fi"},
+		{"\u0130", "i\u0307"},
+		{"\u01C5", "\u01C6"},
+		{"\u65E5\u672C", "\u65E5\u672C"},
+	}
+	for _, tt := range tests {
+		if got := Fold(tt.s); got != tt.want {
+			t.Errorf("Fold(%+q) = %+q, want %+q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestEqualFold(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"Go", "GO", true},
+		{"Go", "Gopher", false},
+		{"Stra\u00DFe", "STRASSE", true},
+		{"\u00E9t\u00E9", "E\u0301TE\u0301", true},
+		{"\u212B", "\u00E5", true},
+		{"\uFB01le", "FILE", true},
+		{"e", "\u00E9", false},
+	}
+	for _, tt := range tests {
+		if got := EqualFold(tt.a, tt.b); got != tt.want {
+			t.Errorf("EqualFold(%+q, %+q) = %t, want %t", tt.a, tt.b, got, tt.want)
+		}
+		if got := EqualFold(tt.b, tt.a); got != tt.want {
+			t.Errorf("EqualFold(%+q, %+q) = %t, want %t", tt.b, tt.a, got, tt.want)
+		}
+		if got := CompareFold(tt.a, tt.b) == 0; got != tt.want {
+			t.Errorf("CompareFold(%+q, %+q) == 0 is %t, want %t", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCompareFold(t *testing.T) {
+	words := []string{"banana", "Apple", "STRASSE", "cherry", "stra\u00DFe", "apricot"}
+	slices.SortStableFunc(words, CompareFold)
+	want := []string{"Apple", "apricot", "banana", "cherry", "STRASSE", "stra\u00DFe"}
+	if !slices.Equal(words, want) {
+		t.Errorf("sorted with CompareFold: %q, want %q", words, want)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		nfd, nfc string
+	}{
+		{"ascii", "cafe", "cafe", "cafe"},
+		{"precomposed", "caf\u00E9", "cafe\u0301", "caf\u00E9"},
+		{"decomposed", "cafe\u0301", "cafe\u0301", "caf\u00E9"},
+		{"two marks", "\u1EC7", "e\u0323\u0302", "\u1EC7"},
+		{"marks out of order", "e\u0302\u0323", "e\u0323\u0302", "\u1EC7"},
+		{"same class kept in order", "a\u0301\u0300", "a\u0301\u0300", "\u00E1\u0300"},
+		{"blocked mark", "a\u0301\u0301", "a\u0301\u0301", "\u00E1\u0301"},
+		{"singleton", "\u212B", "A\u030A", "\u00C5"},
+		{"composition exclusion", "\u0958", "\u0915\u093C", "\u0915\u093C"},
+		{"hangul LV", "\uAC00", "\u1100\u1161", "\uAC00"},
+		{"hangul LVT", "\uD55C", "\u1112\u1161\u11AB", "\uD55C"},
+		{"hangul jamo", "\u1112\u1161\u11AB", "\u1112\u1161\u11AB", "\uD55C"},
+		{"hangul LV and T", "\uD558\u11AB", "\u1112\u1161\u11AB", "\uD55C"},
+		{"starter between", "e\u0301e\u0301", "e\u0301e\u0301", "\u00E9\u00E9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NFD(tt.s); got != tt.nfd {
+				t.Errorf("NFD(%+q) = %+q, want %+q", tt.s, got, tt.nfd)
+			}
+			if got := NFC(tt.s); got != tt.nfc {
+				t.Errorf("NFC(%+q) = %+q, want %+q", tt.s, got, tt.nfc)
+			}
+			if !IsNFD(tt.nfd) || !IsNFC(tt.nfc) {
+				t.Errorf("the normal forms of %+q are not normalized", tt.s)
+			}
+			if got := IsNFC(tt.s); got != (tt.s == tt.nfc) {
+				t.Errorf("IsNFC(%+q) = %t", tt.s, got)
+			}
+			if got := IsNFD(tt.s); got != (tt.s == tt.nfd) {
+				t.Errorf("IsNFD(%+q) = %t", tt.s, got)
+			}
+			if !Equivalent(tt.s, tt.nfd) || !Equivalent(tt.nfc, tt.s) {
+				t.Errorf("%+q is not equivalent to its normal forms", tt.s)
+			}
+		})
+	}
+
+	if Equivalent("\u00E9", "e") {
+		t.Error(`Equivalent("\u00E9", "e") = true`)
+	}
+}
+
+func TestNormalizeIdempotent(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	alphabet := []rune("aeAE\u00E9\u00C5\u212B\u0301\u0300\u0323\u0302\u030A\u1EC7\uAC00\uD55C\u1100\u1161\u11AB\u0958\u093C")
+	for range 1000 {
+		runes := make([]rune, r.IntN(8))
+		for i := range runes {
+			runes[i] = alphabet[r.IntN(len(alphabet))]
+		}
+		s := string(runes)
+
+		nfd, nfc := NFD(s), NFC(s)
+		if NFD(nfd) != nfd || NFC(nfc) != nfc || NFD(nfc) != nfd || NFC(nfd) != nfc {
+			t.Fatalf("normal forms of %+q are not stable: NFD %+q, NFC %+q", s, nfd, nfc)
+		}
+	}
+}
+
+func TestIndex(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	pieces := []string{"a", "\u00E9", "\u65E5", "\U0001F600", "\xff", "\r\n"}
+	inputs := []string{"", "ascii only", strings.Repeat("\u65E5\u672C", 50)}
+	for range 20 {
+		var b strings.Builder
+		for range r.IntN(200) {
+			b.WriteString(pieces[r.IntN(len(pieces))])
+		}
+		inputs = append(inputs, b.String())
+	}
+
+	for _, s := range inputs {
+		x := NewIndex(s)
+		if n := utf8.RuneCountInString(s); x.RuneCount() != n {
+			t.Errorf("RuneCount of %+q = %d, want %d", s, x.RuneCount(), n)
+		}
+
+		pos := 0
+		for offset := range s {
+			if got := x.ByteOffset(pos); got != offset {
+				t.Fatalf("ByteOffset(%d) of %+q = %d, want %d", pos, s, got, offset)
+			}
+			_, size := utf8.DecodeRuneInString(s[offset:])
+			for i := offset; i < offset+size; i++ {
+				if got := x.RuneIndex(i); got != pos {
+					t.Fatalf("RuneIndex(%d) of %+q = %d, want %d", i, s, got, pos)
+				}
+			}
+			pos++
+		}
+		if x.ByteOffset(pos) != len(s) || x.RuneIndex(len(s)) != pos {
+			t.Errorf("the end of %+q is not at rune %d and byte %d", s, pos, len(s))
+		}
+	}
+}
+
+func TestIndexOutOfRange(t *testing.T) {
+	x := NewIndex("\u65E5\u672C")
+	tests := []struct {
+		name string
+		call func()
+	}{
+		{"ByteOffset(-1)", func() { x.ByteOffset(-1) }},
+		{"ByteOffset(3)", func() { x.ByteOffset(3) }},
+		{"RuneIndex(-1)", func() { x.RuneIndex(-1) }},
+		{"RuneIndex(7)", func() { x.RuneIndex(7) }},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", tt.name)
+				}
+			}()
+			tt.call()
+		}()
+	}
+}
@@ -0,0 +1,79 @@
+package text
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// RuneWidth returns the number of columns "r" takes on a terminal:
+// 2 for East Asian wide and fullwidth characters, 0 for control characters,
+// combining marks and the medial and final Hangul jamo, 1 otherwise
+func RuneWidth(r rune) int {
+	switch {
+	case r >= 0x20 && r < 0x7F:
+		return 1
+	case r < 0x20 || (r >= 0x7F && r < 0xA0):
+		return 0
+	case r == 0x200B, r >= 0x1160 && r <= 0x11FF, r >= 0xD7B0 && r <= 0xD7FF:
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case inRanges(wideRanges, r):
+		return 2
+	}
+	return 1
+}
+
+// Width returns the number of columns "s" takes on a terminal.
+// Each grapheme cluster is as wide as its first rune, or 2 columns for emoji
+// presentation sequences and flags, which terminals draw as wide pictures
+func Width(s string) int {
+	w := 0
+	for g := range Graphemes(s) {
+		w += graphemeWidth(g)
+	}
+	return w
+}
+
+func graphemeWidth(g string) int {
+	r, size := utf8.DecodeRuneInString(g)
+	w := RuneWidth(r)
+	if w == 2 || size == len(g) {
+		return w
+	}
+
+	// A variation selector 16 asks for the emoji presentation, two regional indicators make a flag
+	for _, next := range g[size:] {
+		if next == 0xFE0F || classOf(next) == gbRegionalIndicator {
+			return 2
+		}
+		if w == 0 {
+			w = RuneWidth(next)
+		}
+	}
+	return w
+}
+
+// TruncateWidth returns the longest prefix of "s" made of whole grapheme clusters
+// that fits in "width" columns. When "s" does not fit, the prefix is followed by "tail",
+// e.g. "…", and both fit together
+func TruncateWidth(s string, width int, tail string) string {
+	if Width(s) <= width {
+		return s
+	}
+
+	limit := width - Width(tail)
+	if limit < 0 {
+		return ""
+	}
+	w, i := 0, 0
+	for g := range Graphemes(s) {
+		gw := graphemeWidth(g)
+		if w+gw > limit {
+			break
+		}
+		w += gw
+		i += len(g)
+	}
+	return s[:i] + tail
+}